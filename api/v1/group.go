@@ -7,5 +7,6 @@ func Group(route *gin.Engine) {
 	group := route.Group("/api/v1")
 	{
 		group.POST("/review", APIReview)
+		group.GET("/status", APIStatus)
 	}
 }
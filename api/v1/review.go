@@ -3,18 +3,24 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tczekajlo/kir/policy"
 	"github.com/tczekajlo/kir/types"
 )
 
-// APIReview is handler to make image review
+// APIReview is handler to make image review.
+// The trace of the decision is returned in the case when debug query parameter is true.
 func APIReview(c *gin.Context) {
 	var json types.ImageReview
 
 	err := c.BindJSON(&json)
 	if err == nil {
+		if debug, _ := strconv.ParseBool(c.Query("debug")); debug {
+			c.JSON(http.StatusOK, policy.Explain(&json))
+			return
+		}
 		c.JSON(http.StatusOK, policy.Review(&json))
 	} else {
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s", err)})
@@ -0,0 +1,13 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tczekajlo/kir/policy"
+)
+
+// APIStatus is handler which reports state of rules, e.g. rules which cannot be compiled
+func APIStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, policy.Status())
+}
@@ -4,36 +4,74 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/ghodss/yaml"
 
 	"github.com/spf13/cobra"
-	"github.com/tczekajlo/kir/etcd"
+	"github.com/spf13/viper"
 	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/policy"
+	"github.com/tczekajlo/kir/store"
 )
 
 type ruleConfig struct {
-	Name        string
-	Image       []string
-	Allowed     bool
-	Annotations []string
-	Namespace   string
-	Reason      string
+	Name           string
+	Image          []string
+	Registry       string
+	Repository     string
+	Tag            string
+	Digest         string
+	Semver         string
+	InvalidSemver  string
+	ImageMatchType string
+
+	Allowed              bool
+	Annotations          []string
+	AnnotationsMatch     string
+	AnnotationsMatchType string
+	ForbiddenAnnotations []string
+	Namespace            string
+	NamespaceMatchType   string
+	NamespaceSelector    string
+	AllNamespaces        bool
+	Reason               string
+	Metadata             []string
+	Priority             int32
+	Mode                 string
+	Condition            string
+
+	RequireDigest   bool
+	DenyMutableTags bool
+	MutableTags     []string
+
+	ExcludeImages     []string
+	ExcludeNamespaces []string
+
+	ValidFrom  string
+	ValidUntil string
+	Windows    []string
+	TTL        time.Duration
+
+	// ResourceVersion is the version of the rule which is overridden
+	ResourceVersion int64
 }
 
 var rule ruleConfig
 
 func setupValidate() error {
-	if len(rule.Image) == 0 {
-		return fmt.Errorf("List of images is empty. Use --image flag")
+	if len(rule.Image) == 0 && !rule.hasImageParts() {
+		return fmt.Errorf("List of images is empty. Use --image, --registry, --repository, --tag, --digest or --semver flag")
 	}
 
 	if rule.Name == "" {
 		return fmt.Errorf("Rule name is empty. Use --name flag")
 	}
 
-	if rule.Namespace == "" {
-		return fmt.Errorf("Namespace name is empty. Use --namespace flag")
+	// a rule without namespace matches to all namespaces, e.g. rules of policy sets,
+	// thus it has to be requested explicitly
+	if rule.Namespace == "" && rule.NamespaceSelector == "" && !rule.AllNamespaces {
+		return fmt.Errorf("Namespace name is empty. Use --namespace flag, or --all-namespaces flag for a rule of all namespaces, e.g. a rule of policy set")
 	}
 
 	return nil
@@ -43,7 +81,21 @@ func setupRule(data *pb.Rule) (*pb.Rule, error) {
 	var err error
 
 	if len(rule.Annotations) != 0 {
-		data.Annotations, err = rule.annotationsToMap()
+		data.Annotations, err = annotationsToMap(rule.Annotations)
+		if err != nil {
+			return data, fmt.Errorf("%s", err)
+		}
+	}
+
+	if len(rule.Metadata) != 0 {
+		data.Metadata, err = annotationsToMap(rule.Metadata)
+		if err != nil {
+			return data, fmt.Errorf("%s", err)
+		}
+	}
+
+	if len(rule.ForbiddenAnnotations) != 0 {
+		data.ForbiddenAnnotations, err = annotationsToMap(rule.ForbiddenAnnotations)
 		if err != nil {
 			return data, fmt.Errorf("%s", err)
 		}
@@ -51,15 +103,35 @@ func setupRule(data *pb.Rule) (*pb.Rule, error) {
 
 	data.Containers = rule.imageToContainerImage()
 
+	for _, image := range rule.ExcludeImages {
+		data.ExcludeImages = append(data.ExcludeImages, &pb.Rule_Containers{Image: image, MatchType: rule.ImageMatchType})
+	}
+
+	for _, value := range rule.Windows {
+		window, err := policy.ParseWindow(value)
+		if err != nil {
+			return data, err
+		}
+		data.Windows = append(data.Windows, window)
+	}
+
 	return data, nil
 }
 
-func (r *ruleConfig) annotationsToMap() (map[string]string, error) {
+// annotationsToMap converts annotations in the form key=value or key into map.
+// Only the first "=" separates key from value, the annotation without value
+// means that only presence of key is checked.
+func annotationsToMap(annotations []string) (map[string]string, error) {
 	var result = make(map[string]string)
-	for _, annotation := range rule.Annotations {
-		data := strings.Split(annotation, "=")
-		if len(data) < 2 {
-			return result, fmt.Errorf("Cannot parse annotation")
+	for _, annotation := range annotations {
+		data := strings.SplitN(annotation, "=", 2)
+		if data[0] == "" {
+			return result, fmt.Errorf("Cannot parse annotation %s", annotation)
+		}
+
+		if len(data) == 1 {
+			result[data[0]] = ""
+			continue
 		}
 
 		result[data[0]] = data[1]
@@ -68,10 +140,28 @@ func (r *ruleConfig) annotationsToMap() (map[string]string, error) {
 	return result, nil
 }
 
+func (r *ruleConfig) hasImageParts() bool {
+	return r.Registry != "" || r.Repository != "" || r.Tag != "" || r.Digest != "" || r.Semver != ""
+}
+
 func (r *ruleConfig) imageToContainerImage() []*pb.Rule_Containers {
 	var result []*pb.Rule_Containers
 	for _, image := range rule.Image {
-		result = append(result, &pb.Rule_Containers{Image: image})
+		result = append(result, &pb.Rule_Containers{Image: image, MatchType: r.ImageMatchType})
+	}
+
+	// parts of image are matched together as one container
+	if r.hasImageParts() {
+		result = append(result, &pb.Rule_Containers{
+			Registry:   r.Registry,
+			Repository: r.Repository,
+			Tag:        r.Tag,
+			Digest:     r.Digest,
+
+			Semver:        r.Semver,
+			InvalidSemver: r.InvalidSemver,
+			MatchType:     r.ImageMatchType,
+		})
 	}
 
 	return result
@@ -87,6 +177,39 @@ For example:
 # Allows for run PODs with nginx image in 1.x version within default namespace
 kir add --name my_rule --image ^nginx:1.[0-9] --allowed --namespace ^default$
 
+# Denies images which are not pinned by digest within production namespace
+kir add --name pinned --image . --require-digest --allowed --namespace ^production$
+
+# Allows for run PODs with nginx image in version >= 1.19 and < 2.0
+kir add --name nginx --repository ^library/nginx$ --semver ">= 1.19, < 2.0" --allowed --namespace .
+
+# Allows for run PODs with any image from myrepo within namespaces which names start with team-
+kir add --name team --image myrepo/* --image-match-type glob --namespace team- --namespace-match-type prefix --allowed
+
+# Bans nginx images except nginx-ingress within all namespaces except kube-system
+kir add --name no_nginx --image ^nginx --exclude-images ^nginx-ingress --namespace . --exclude-namespaces ^kube-system$
+
+# Allows for run PODs with any image if both team and ticket annotations are present
+kir add --name annotated --image . --namespace . --annotations "team.image-policy.k8s.io/name,ticket.image-policy.k8s.io/id=^[0-9]+$" --annotations-match all --allowed
+
+# Denies images which are not pinned by digest within namespaces labeled env=prod
+kir add --name prod_pinned --image . --namespace-selector env=prod --require-digest --allowed
+
+# Allows for run PODs with any image from gcr.io registry
+kir add --name gcr --registry ^gcr\.io$ --allowed --namespace .
+
+# Allows for run PODs within team- namespaces only with images from the registry of the team
+kir add --name team_registry --image . --namespace ^team- --condition 'image.registry == request.namespace.replace("team-", "") + ".registry.example.com"' --allowed
+
+# Bans images from Docker Hub with the reason pointing to documentation
+kir add --name no_docker_hub --registry ^docker\.io$ --namespace . --metadata docs=https://wiki.example.com/images --reason "{{.Repository}} from {{.Registry}} is not allowed in {{.Namespace}}, see {{.Metadata.docs}}"
+
+# Allows for run PODs with any image within staging namespace on weekdays during working hours
+kir add --name office_hours --image . --namespace ^staging$ --window "mon-fri 09:00-17:00 Europe/Warsaw" --allowed
+
+# Allows for run PODs with debug image within any namespace for the next 2 hours
+kir add --name debug --image ^busybox --namespace . --ttl 2h --allowed
+
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		var err error
@@ -105,6 +228,22 @@ kir add --name my_rule --image ^nginx:1.[0-9] --allowed --namespace ^default$
 				Allowed:   rule.Allowed,
 				Namespace: rule.Namespace,
 				Reason:    rule.Reason,
+				Priority:  rule.Priority,
+				Mode:      rule.Mode,
+				Condition: rule.Condition,
+
+				RequireDigest:   rule.RequireDigest,
+				DenyMutableTags: rule.DenyMutableTags,
+				MutableTags:     rule.MutableTags,
+
+				NamespaceMatchType:   rule.NamespaceMatchType,
+				NamespaceSelector:    rule.NamespaceSelector,
+				AnnotationsMatchType: rule.AnnotationsMatchType,
+				AnnotationsMatch:     rule.AnnotationsMatch,
+				ExcludeNamespaces:    rule.ExcludeNamespaces,
+
+				ValidFrom:  rule.ValidFrom,
+				ValidUntil: rule.ValidUntil,
 			})
 			if err != nil {
 				fmt.Println(err)
@@ -125,19 +264,55 @@ kir add --name my_rule --image ^nginx:1.[0-9] --allowed --namespace ^default$
 			}
 		}
 
-		// etcd
-		etcd := etcd.Client{}
-		etcd.New()
 		override, _ := cmd.Flags().GetBool("override")
-		err = etcd.Add(data, override)
+		if rule.ResourceVersion != 0 {
+			data.ResourceVersion = rule.ResourceVersion
+		}
+
+		// without resource version the rule would silently override changes made by someone else
+		if force, _ := cmd.Flags().GetBool("force"); override && data.ResourceVersion == 0 && !force {
+			fmt.Println("Resource version of the rule is not given. Use --resource-version flag or resource_version field of the file to override the rule only if nobody changed it in the meantime, or --force flag to override it anyway")
+			return
+		}
+
+		// the rule expires after ttl, the store removes it
+		if rule.TTL > 0 {
+			data.ValidUntil = time.Now().Add(rule.TTL).UTC().Format(time.RFC3339)
+		}
+
+		err = policy.Validate(data)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		var added bool
+		if rule.TTL > 0 {
+			ttlStore, ok := storage.(store.TTLStore)
+			if !ok {
+				fmt.Printf("Store %s does not support --ttl flag\n", viper.GetString("store.backend"))
+				return
+			}
+			added, err = ttlStore.AddWithTTL(store.Rule, data.Name, data, override, rule.TTL)
+		} else {
+			added, err = storage.Add(store.Rule, data.Name, data, override)
+		}
 		if err != nil {
-			fmt.Println("Cannot add rule")
+			fmt.Println("Cannot add rule:", err)
 			return
 		}
-		defer etcd.Client.Close()
 
-		if etcd.TxnResponse.Succeeded {
+		if added {
 			fmt.Printf("Rule \"%s\" added.\n", data.Name)
+		} else if override && data.ResourceVersion != 0 {
+			fmt.Printf("Rule \"%s\" was changed by someone else since resource version %d, get the current version and try again.\n", data.Name, data.ResourceVersion)
 		} else {
 			fmt.Printf("Rule \"%s\" already exists.\n", data.Name)
 		}
@@ -150,12 +325,40 @@ func init() {
 	RootCmd.AddCommand(addCmd)
 
 	addCmd.Flags().StringSliceVar(&rule.Image, "image", []string{}, "container image name (items in a list should be separated by a comma)")
-	addCmd.Flags().StringSliceVar(&rule.Annotations, "annotations", []string{}, "list of annotations, e.g. key=value (items in a list should be separated by a comma)")
+	addCmd.Flags().StringVar(&rule.Registry, "registry", "", "registry of image, e.g. docker.io")
+	addCmd.Flags().StringVar(&rule.Repository, "repository", "", "repository of image, e.g. library/nginx")
+	addCmd.Flags().StringVar(&rule.Tag, "tag", "", "tag of image")
+	addCmd.Flags().StringVar(&rule.Digest, "digest", "", "digest of image, e.g. sha256:...")
+	addCmd.Flags().StringVar(&rule.Semver, "semver", "", "semantic version constraint for tag of image, e.g. \">= 1.19, < 2.0\"")
+	addCmd.Flags().StringVar(&rule.InvalidSemver, "invalid-semver", policy.InvalidSemverNoMatch, "how to handle tag which is not semantic version (no-match, deny, regex)")
+	addCmd.Flags().StringSliceVar(&rule.ExcludeImages, "exclude-images", []string{}, "container images excluded from the rule (items in a list should be separated by a comma)")
+	addCmd.Flags().StringVar(&rule.ImageMatchType, "image-match-type", "", "syntax of image patterns: regex (default), anchored-regex, glob, exact, prefix")
+	addCmd.Flags().StringSliceVar(&rule.Annotations, "annotations", []string{}, "list of annotations, e.g. key=value or key to check only presence of key (items in a list should be separated by a comma)")
+	addCmd.Flags().StringVar(&rule.AnnotationsMatch, "annotations-match", "", "which of annotations have to be present: any (default), all")
+	addCmd.Flags().StringSliceVar(&rule.ForbiddenAnnotations, "forbidden-annotations", []string{}, "list of annotations which must not be present, e.g. key=value or key (items in a list should be separated by a comma)")
 	addCmd.Flags().StringVar(&rule.Namespace, "namespace", "", "namespace name")
+	addCmd.Flags().BoolVar(&rule.AllNamespaces, "all-namespaces", false, "the rule matches to all namespaces, e.g. a rule of policy set, instead of --namespace")
+	addCmd.Flags().StringVar(&rule.NamespaceSelector, "namespace-selector", "", "label selector of namespaces, e.g. \"env=prod,team in (payments,risk)\"")
+	addCmd.Flags().StringSliceVar(&rule.ExcludeNamespaces, "exclude-namespaces", []string{}, "namespaces excluded from the rule (items in a list should be separated by a comma)")
+	addCmd.Flags().StringVar(&rule.NamespaceMatchType, "namespace-match-type", "", "syntax of namespace pattern: regex (default), anchored-regex, glob, exact, prefix")
+	addCmd.Flags().StringVar(&rule.AnnotationsMatchType, "annotations-match-type", "", "syntax of annotation patterns: regex (default), anchored-regex, glob, exact, prefix")
 	addCmd.Flags().StringVar(&rule.Name, "name", "", "rule name")
-	addCmd.Flags().StringVar(&rule.Reason, "reason", "", "reason why for this rule is blocking image")
+	addCmd.Flags().StringVar(&rule.Reason, "reason", "", "reason why for this rule is blocking image, Go template with .Image, .Registry, .Repository, .Tag, .Digest, .Namespace, .Rule and .Metadata")
+	addCmd.Flags().StringSliceVar(&rule.Metadata, "metadata", []string{}, "metadata of rule available in template of reason, e.g. docs=https://wiki/images (items in a list should be separated by a comma)")
+	addCmd.Flags().Int32Var(&rule.Priority, "priority", 0, "rule priority, rules with higher priority are evaluated first")
+	addCmd.Flags().StringVar(&rule.Mode, "mode", "", "enforcement mode of rule: enforce (default), warn, audit")
+	addCmd.Flags().StringVar(&rule.Condition, "condition", "", "CEL expression which has to be true for the rule to match, e.g. 'image.tag != \"latest\"'")
 	addCmd.Flags().BoolVar(&rule.Allowed, "allowed", false, "action to take if request match to a rule")
+	addCmd.Flags().BoolVar(&rule.RequireDigest, "require-digest", false, "deny images which are not referenced by sha256 digest")
+	addCmd.Flags().BoolVar(&rule.DenyMutableTags, "deny-mutable-tags", false, "deny images with mutable tag or without tag")
+	addCmd.Flags().StringSliceVar(&rule.MutableTags, "mutable-tags", []string{}, "list of mutable tags (default \"latest\")")
+	addCmd.Flags().StringVar(&rule.ValidFrom, "valid-from", "", "time from which the rule is valid in RFC3339 format, e.g. 2017-05-14T09:00:00Z")
+	addCmd.Flags().StringVar(&rule.ValidUntil, "valid-until", "", "time until which the rule is valid in RFC3339 format")
+	addCmd.Flags().StringArrayVar(&rule.Windows, "window", []string{}, "recurring window when the rule is valid, e.g. \"mon-fri 09:00-17:00 Europe/Warsaw\" (can be repeated)")
+	addCmd.Flags().DurationVar(&rule.TTL, "ttl", 0, "time after which the rule expires and is removed, e.g. 2h")
 	addCmd.Flags().Bool("override", false, "override existing rule")
+	addCmd.Flags().Int64Var(&rule.ResourceVersion, "resource-version", 0, "resource version of existing rule, --override overrides the rule only if it is still the current one")
+	addCmd.Flags().Bool("force", false, "override existing rule without resource version")
 
 	addCmd.Flags().StringP("file", "f", "", "add rule based on data from a file")
 }
@@ -0,0 +1,389 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/proto"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/policy"
+	"github.com/tczekajlo/kir/store"
+)
+
+// Actions of plan of kir apply
+const (
+	applyCreate = "create"
+	applyUpdate = "update"
+	applyDelete = "delete"
+)
+
+// manifest is a rule read from a YAML document
+type manifest struct {
+	rule *pb.Rule
+
+	// source is the file and number of document, e.g. rules/banned.yaml#2
+	source string
+}
+
+// applyStep is a change of plan of kir apply
+type applyStep struct {
+	action string
+	source string
+	change *store.Change
+}
+
+// applyCmd represents the apply command
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Creates or updates rules from YAML files",
+	Long: `Creates or updates rules from a YAML file, all YAML files of a directory (including
+subdirectories, hidden ones are skipped) or standard input given by "-". A file may contain
+several rules separated by "---". Rules have the same format as files of kir add -f command.
+
+All changes are made in one transaction, thus either all rules are applied or none of them,
+e.g. in the case when somebody changed rules in the meantime. In the case when the number
+of changes exceeds --etcd-max-txn-ops, changes are split into several transactions.
+Other stores make changes one by one and stop at the first rule changed in the meantime,
+the number of changes made before it is printed.
+
+For example:
+
+# Shows which rules would be created, updated or deleted
+kir apply -f rules/ --prune --dry-run
+
+# Applies rules and deletes rules which are not in rules/ directory
+kir apply -f rules/ --prune
+
+cat rules.yaml | kir apply -f -
+
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		path := cmd.Flag("file").Value.String()
+		if path == "" {
+			fmt.Println("You have to give a file or a directory with rules. Use -f flag")
+			return
+		}
+
+		manifests, err := readManifests(path)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		// pruning without rules deletes all rules, e.g. because of a wrong path,
+		// thus it has to be requested explicitly
+		prune, _ := cmd.Flags().GetBool("prune")
+		pruneAll, _ := cmd.Flags().GetBool("prune-all")
+		if prune && len(manifests) == 0 && !pruneAll {
+			fmt.Printf("No rules found in %s, --prune would delete all rules. Use --prune-all flag to do it anyway\n", path)
+			return
+		}
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		current, _, err := store.GetRules(storage, 0)
+		if err != nil {
+			fmt.Println("Cannot get rules:", err)
+			return
+		}
+
+		steps, unchanged := applyPlan(manifests, current.Rule, prune)
+
+		//print output
+		if len(steps) != 0 {
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Name", "Action", "Source"})
+			table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+			table.SetCenterSeparator(" ")
+			table.SetColumnSeparator(" ")
+
+			for _, step := range steps {
+				table.Append([]string{step.change.Name, step.action, step.source})
+			}
+			table.Render()
+		}
+		fmt.Printf("Plan: %d to create, %d to update, %d to delete, %d unchanged.\n",
+			countSteps(steps, applyCreate), countSteps(steps, applyUpdate), countSteps(steps, applyDelete), unchanged)
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun || len(steps) == 0 {
+			return
+		}
+
+		var changes []*store.Change
+		for _, step := range steps {
+			changes = append(changes, step.change)
+		}
+
+		applied, err := store.Apply(storage, changes)
+		switch {
+		case err == store.ErrConflict && applied == 0:
+			fmt.Println("Rules were changed in the meantime, nothing is applied. Run kir apply again.")
+		case err == store.ErrConflict:
+			fmt.Printf("Rules were changed in the meantime, %d of %d changes are applied. Run kir apply again.\n", applied, len(changes))
+		case err != nil:
+			fmt.Printf("Cannot apply rules: %s, %d of %d changes are applied\n", err, applied, len(changes))
+		default:
+			fmt.Println("Rules applied. Changes made:", applied)
+		}
+	},
+}
+
+// applyPlan compares rules of manifests with the current rules and returns changes
+// which make them the same, together with number of unchanged rules. Rules which are
+// not in manifests are deleted only in the case when prune is true.
+func applyPlan(manifests []*manifest, current []*pb.Rule, prune bool) ([]*applyStep, int) {
+	var steps []*applyStep
+	var unchanged int
+
+	rules := make(map[string]*pb.Rule)
+	for _, rule := range current {
+		rules[rule.Name] = rule
+	}
+
+	applied := make(map[string]bool)
+	for _, m := range manifests {
+		applied[m.rule.Name] = true
+
+		step := &applyStep{
+			action: applyCreate,
+			source: m.source,
+			change: &store.Change{Kind: store.Rule, Name: m.rule.Name, Data: m.rule},
+		}
+
+		if rule, exists := rules[m.rule.Name]; exists {
+			stored := proto.Clone(rule).(*pb.Rule)
+			stored.ResourceVersion = 0
+			if proto.Equal(stored, m.rule) {
+				unchanged++
+				continue
+			}
+
+			step.action = applyUpdate
+			step.change.ResourceVersion = rule.ResourceVersion
+		}
+		steps = append(steps, step)
+	}
+
+	if prune {
+		for _, rule := range current {
+			if applied[rule.Name] {
+				continue
+			}
+
+			steps = append(steps, &applyStep{
+				action: applyDelete,
+				change: &store.Change{Kind: store.Rule, Name: rule.Name, ResourceVersion: rule.ResourceVersion},
+			})
+		}
+	}
+
+	return steps, unchanged
+}
+
+// seedStore fills memory store with rules of the file or directory, the same way
+// as kir apply does. Nothing is done in the case when the seed is not given.
+func seedStore(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	if backend := viper.GetString("store.backend"); backend != store.Memory {
+		return fmt.Errorf("Seed %s is supported only by %s store, not by %s", path, store.Memory, backend)
+	}
+
+	manifests, err := readManifests(path)
+	if err != nil {
+		return fmt.Errorf("Cannot read seed of store: %s", err)
+	}
+
+	storage, err := store.New()
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	current, _, err := store.GetRules(storage, 0)
+	if err != nil {
+		return fmt.Errorf("Cannot get rules: %s", err)
+	}
+
+	var changes []*store.Change
+	steps, _ := applyPlan(manifests, current.Rule, false)
+	for _, step := range steps {
+		changes = append(changes, step.change)
+	}
+
+	if _, err := store.Apply(storage, changes); err != nil {
+		return fmt.Errorf("Cannot seed store: %s", err)
+	}
+
+	return nil
+}
+
+func countSteps(steps []*applyStep, action string) int {
+	var count int
+	for _, step := range steps {
+		if step.action == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+// readManifests reads rules from the file, YAML files of the directory or standard input
+// in the case of "-". Rules are validated and every rule can be given only once.
+func readManifests(path string) ([]*manifest, error) {
+	var result []*manifest
+
+	if path == "-" {
+		content, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+
+		if result, err = parseManifests("stdin", content); err != nil {
+			return nil, err
+		}
+	} else {
+		files, err := manifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			content, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("Cannot read %s: %s", file, err)
+			}
+
+			manifests, err := parseManifests(file, content)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, manifests...)
+		}
+	}
+
+	sources := make(map[string]string)
+	for _, m := range result {
+		if source, exists := sources[m.rule.Name]; exists {
+			return nil, fmt.Errorf("Rule %s is given twice, in %s and %s", m.rule.Name, source, m.source)
+		}
+		sources[m.rule.Name] = m.source
+	}
+
+	return result, nil
+}
+
+// manifestFiles returns the file, or YAML files of the directory and its subdirectories
+// sorted by path. Hidden files and directories are skipped.
+func manifestFiles(path string) ([]string, error) {
+	var files []string
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if file != path && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if ext := filepath.Ext(file); !info.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, file)
+		}
+
+		return nil
+	})
+	sort.Strings(files)
+
+	return files, err
+}
+
+// parseManifests parses rules of YAML documents separated by "---",
+// empty documents are skipped
+func parseManifests(file string, content []byte) ([]*manifest, error) {
+	var result []*manifest
+
+	for i, document := range splitDocuments(content) {
+		source := fmt.Sprintf("%s#%d", file, i+1)
+
+		var fields map[string]interface{}
+		if err := yaml.Unmarshal(document, &fields); err != nil {
+			return nil, fmt.Errorf("Cannot parse %s: %s", source, err)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		rule := &pb.Rule{}
+		if err := yaml.Unmarshal(document, rule); err != nil {
+			return nil, fmt.Errorf("Cannot parse %s: %s", source, err)
+		}
+
+		// resource version of the current rule is used, the one of file is out of date
+		rule.ResourceVersion = 0
+
+		if rule.Name == "" {
+			return nil, fmt.Errorf("Rule in %s has no name", source)
+		}
+		if err := policy.Validate(rule); err != nil {
+			return nil, fmt.Errorf("%s: %s", source, err)
+		}
+
+		result = append(result, &manifest{rule: rule, source: source})
+	}
+
+	return result, nil
+}
+
+// splitDocuments splits YAML into documents separated by "---" lines
+func splitDocuments(content []byte) [][]byte {
+	var result [][]byte
+	var document []string
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimRight(line, " \t\r") == "---" {
+			result = append(result, []byte(strings.Join(document, "\n")))
+			document = nil
+			continue
+		}
+		document = append(document, line)
+	}
+
+	return append(result, []byte(strings.Join(document, "\n")))
+}
+
+func init() {
+	RootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().StringP("file", "f", "", "a YAML file, a directory with YAML files or - for standard input")
+	applyCmd.Flags().Bool("dry-run", false, "show the plan without applying it")
+	applyCmd.Flags().Bool("prune", false, "delete rules which are not given")
+	applyCmd.Flags().Bool("prune-all", false, "allow --prune to delete all rules in the case when no rules are given")
+}
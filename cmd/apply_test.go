@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/store"
+)
+
+const testManifests = `
+name: banned
+namespace: ^default$
+containers:
+- image: ^nginx$
+---
+# only a comment
+---
+name: pinned
+namespace: .
+allowed: true
+containers:
+- digest: .
+---
+name: trusted
+namespace: .
+allowed: true
+resource_version: 42
+containers:
+- registry: ^gcr\.io$
+`
+
+func TestParseManifests(t *testing.T) {
+	manifests, err := parseManifests("rules.yaml", []byte(testManifests))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sources []string
+	for _, m := range manifests {
+		sources = append(sources, m.rule.Name+"@"+m.source)
+	}
+
+	// the document with a comment only is skipped, but it is counted
+	if got := strings.Join(sources, " "); got != "banned@rules.yaml#1 pinned@rules.yaml#3 trusted@rules.yaml#4" {
+		t.Errorf("got %s", got)
+	}
+
+	// resource version of the file is out of date
+	if version := manifests[2].rule.ResourceVersion; version != 0 {
+		t.Errorf("got resource version %d, want 0", version)
+	}
+}
+
+func TestParseManifestsErrors(t *testing.T) {
+	if _, err := parseManifests("rules.yaml", []byte("namespace: .")); err == nil || err.Error() != "Rule in rules.yaml#1 has no name" {
+		t.Errorf("rule without name: got error %v", err)
+	}
+
+	if _, err := parseManifests("rules.yaml", []byte("name: a\n---\nname: b\nnamespace: ((")); err == nil || !strings.HasPrefix(err.Error(), "rules.yaml#2: ") {
+		t.Errorf("invalid rule: got error %v", err)
+	}
+
+	if _, err := parseManifests("rules.yaml", []byte("name: [a")); err == nil || !strings.HasPrefix(err.Error(), "Cannot parse rules.yaml#1") {
+		t.Errorf("invalid YAML: got error %v", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	storage := store.NewMemory()
+	for _, rule := range []*pb.Rule{
+		{Name: "banned", Namespace: "^default$", Containers: []*pb.Rule_Containers{{Image: "^nginx$"}}},
+		{Name: "pinned", Namespace: ".", Containers: []*pb.Rule_Containers{{Digest: "."}}},
+		{Name: "old", Namespace: ".", Containers: []*pb.Rule_Containers{{Image: "^httpd$"}}},
+	} {
+		if _, err := storage.Add(store.Rule, rule.Name, rule, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifests, err := parseManifests("rules.yaml", []byte(testManifests))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current, _, err := store.GetRules(storage, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// banned is unchanged, pinned is allowed now and trusted is new
+	steps, unchanged := applyPlan(manifests, current.Rule, false)
+	if unchanged != 1 || countSteps(steps, applyCreate) != 1 || countSteps(steps, applyUpdate) != 1 || countSteps(steps, applyDelete) != 0 {
+		t.Fatalf("without prune: got %d steps and %d unchanged", len(steps), unchanged)
+	}
+
+	// old is not in manifests
+	steps, _ = applyPlan(manifests, current.Rule, true)
+	if countSteps(steps, applyDelete) != 1 {
+		t.Fatalf("with prune: got %d deletes, want 1", countSteps(steps, applyDelete))
+	}
+
+	var changes []*store.Change
+	for _, step := range steps {
+		changes = append(changes, step.change)
+	}
+	if applied, err := store.Apply(storage, changes); err != nil || applied != len(changes) {
+		t.Fatalf("got %d of %d changes applied: %v", applied, len(changes), err)
+	}
+
+	current, _, err = store.GetRules(storage, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if steps, unchanged := applyPlan(manifests, current.Rule, true); len(steps) != 0 || unchanged != len(manifests) {
+		t.Errorf("after apply: got %d steps and %d unchanged, want nothing to do", len(steps), unchanged)
+	}
+
+	// a rule changed in the meantime is not overridden
+	steps, _ = applyPlan(manifests[:1], []*pb.Rule{{Name: "banned", ResourceVersion: 1000}}, false)
+	if applied, err := store.Apply(storage, []*store.Change{steps[0].change}); err != store.ErrConflict || applied != 0 {
+		t.Errorf("got %d changes applied and error %v, want conflict", applied, err)
+	}
+}
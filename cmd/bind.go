@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/policy"
+	"github.com/tczekajlo/kir/store"
+)
+
+var binding pb.Binding
+
+// bindCmd represents the bind command
+var bindCmd = &cobra.Command{
+	Use:   "bind",
+	Short: "Manages bindings of policy sets to namespaces",
+	Long: `Manages bindings which map namespace patterns to policy sets.
+In the case when any binding exists, rules of a set are evaluated only for namespaces
+the set is bound to. Sets are evaluated in order of bindings (by name) and sets in the binding,
+rules of a set by priority. Rules which are not in any set are evaluated by priority
+for every namespace, after rules of bound sets. Without bindings all rules are evaluated
+by priority.
+For example:
+
+# Binds production set to namespaces which names start with prod-
+kir bind add --name production --namespaces prod- --namespace-match-type prefix --sets production
+
+`,
+}
+
+var bindAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Adds a new binding",
+	Run: func(cmd *cobra.Command, args []string) {
+		if binding.Name == "" {
+			fmt.Println("Binding name is empty. Use --name flag")
+			return
+		}
+
+		err := policy.ValidateBinding(&binding)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		override, _ := cmd.Flags().GetBool("override")
+		added, err := storage.Add(store.Binding, binding.Name, &binding, override)
+		if err != nil {
+			fmt.Println("Cannot add binding")
+			return
+		}
+
+		if added {
+			fmt.Printf("Binding \"%s\" added.\n", binding.Name)
+		} else {
+			fmt.Printf("Binding \"%s\" already exists.\n", binding.Name)
+		}
+	},
+}
+
+var bindGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Gets the binding or all bindings",
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		var data *pb.BindingsList
+		var dataBinding *pb.Binding
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		if len(args) == 0 {
+			data, err = store.GetBindings(storage)
+		} else {
+			dataBinding, err = store.GetBinding(storage, args[0])
+			data = &pb.BindingsList{}
+			data.Binding = append(data.Binding, dataBinding)
+		}
+		if err != nil {
+			fmt.Println("Cannot get binding(s):", err)
+			return
+		}
+
+		//print output
+		if len(args) != 0 && cmd.Flag("output").Value.String() != "" {
+			printOutput(dataBinding, cmd.Flag("output").Value.String())
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Namespaces", "Sets"})
+		table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+		table.SetCenterSeparator(" ")
+		table.SetColumnSeparator(" ")
+
+		for _, data := range data.Binding {
+			table.Append([]string{data.Name,
+				withMatchType(strings.Join(data.Namespaces, "\n"), data.NamespaceMatchType),
+				strings.Join(data.Sets, "\n"),
+			})
+		}
+		table.Render()
+	},
+}
+
+var bindDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Deletes a binding",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println("You have to give a name of binding to delete")
+			return
+		}
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		deleted, err := storage.Delete(store.Binding, args[0])
+		if err != nil {
+			fmt.Println("Cannot delete binding:", err)
+			return
+		}
+
+		fmt.Println("Deleted bindings:", deleted)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(bindCmd)
+	bindCmd.AddCommand(bindAddCmd)
+	bindCmd.AddCommand(bindGetCmd)
+	bindCmd.AddCommand(bindDeleteCmd)
+
+	bindAddCmd.Flags().StringVar(&binding.Name, "name", "", "binding name")
+	bindAddCmd.Flags().StringSliceVar(&binding.Namespaces, "namespaces", []string{}, "namespace patterns (items in a list should be separated by a comma)")
+	bindAddCmd.Flags().StringVar(&binding.NamespaceMatchType, "namespace-match-type", "", "syntax of namespace patterns: regex (default), anchored-regex, glob, exact, prefix")
+	bindAddCmd.Flags().StringSliceVar(&binding.Sets, "sets", []string{}, "names of sets in order of evaluation (items in a list should be separated by a comma)")
+	bindAddCmd.Flags().Bool("override", false, "override existing binding")
+
+	bindGetCmd.Flags().StringP("output", "o", "", "set the output format (yaml)")
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/policy"
+	"github.com/tczekajlo/kir/store"
+)
+
+var namespaceDefault pb.Default
+
+func defaultValidate() error {
+	if namespaceDefault.Name == "" {
+		return fmt.Errorf("Default name is empty. Use --name flag")
+	}
+
+	if namespaceDefault.Namespace == "" {
+		return fmt.Errorf("Namespace name is empty. Use --namespace flag")
+	}
+
+	return policy.ValidateDefault(&namespaceDefault)
+}
+
+// defaultCmd represents the default command
+var defaultCmd = &cobra.Command{
+	Use:   "default",
+	Short: "Manages default decisions for namespaces",
+	Long: `Manages decisions for images which do not match to any rule.
+A default overrides the decision from server configuration for namespaces which match to it.
+For example:
+
+# Allows for run images which do not match to any rule within sandbox namespaces
+kir default add --name sandbox --namespace ^sandbox- --allowed
+
+`,
+}
+
+var defaultAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Adds a new default decision",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := defaultValidate()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		override, _ := cmd.Flags().GetBool("override")
+		added, err := storage.Add(store.Default, namespaceDefault.Name, &namespaceDefault, override)
+		if err != nil {
+			fmt.Println("Cannot add default")
+			return
+		}
+
+		if added {
+			fmt.Printf("Default \"%s\" added.\n", namespaceDefault.Name)
+		} else {
+			fmt.Printf("Default \"%s\" already exists.\n", namespaceDefault.Name)
+		}
+	},
+}
+
+var defaultGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Gets the default decision or all default decisions",
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		var data *pb.DefaultsList
+		var dataDefault *pb.Default
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		if len(args) == 0 {
+			data, err = store.GetDefaults(storage)
+		} else {
+			dataDefault, err = store.GetDefault(storage, args[0])
+			data = &pb.DefaultsList{}
+			data.Default = append(data.Default, dataDefault)
+		}
+		if err != nil {
+			fmt.Println("Cannot get default(s):", err)
+			return
+		}
+
+		//print output
+		if len(args) != 0 && cmd.Flag("output").Value.String() != "" {
+			printOutput(dataDefault, cmd.Flag("output").Value.String())
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Namespace", "Allowed", "Reason"})
+		table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+		table.SetCenterSeparator(" ")
+		table.SetColumnSeparator(" ")
+
+		for _, data := range data.Default {
+			table.Append([]string{data.Name,
+				withMatchType(data.Namespace, data.NamespaceMatchType),
+				strconv.FormatBool(data.Allowed),
+				data.Reason,
+			})
+		}
+		table.Render()
+	},
+}
+
+var defaultDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Deletes a default decision",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println("You have to give a name of default to delete")
+			return
+		}
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		deleted, err := storage.Delete(store.Default, args[0])
+		if err != nil {
+			fmt.Println("Cannot delete default:", err)
+			return
+		}
+
+		fmt.Println("Deleted defaults:", deleted)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(defaultCmd)
+	defaultCmd.AddCommand(defaultAddCmd)
+	defaultCmd.AddCommand(defaultGetCmd)
+	defaultCmd.AddCommand(defaultDeleteCmd)
+
+	defaultAddCmd.Flags().StringVar(&namespaceDefault.Name, "name", "", "default name")
+	defaultAddCmd.Flags().StringVar(&namespaceDefault.Namespace, "namespace", "", "namespace name")
+	defaultAddCmd.Flags().StringVar(&namespaceDefault.NamespaceMatchType, "namespace-match-type", "", "syntax of namespace pattern: regex (default), anchored-regex, glob, exact, prefix")
+	defaultAddCmd.Flags().BoolVar(&namespaceDefault.Allowed, "allowed", false, "decision for images which do not match to any rule")
+	defaultAddCmd.Flags().StringVar(&namespaceDefault.Reason, "reason", "", "reason of the decision")
+	defaultAddCmd.Flags().Bool("override", false, "override existing default")
+
+	defaultGetCmd.Flags().StringP("output", "o", "", "set the output format (yaml)")
+}
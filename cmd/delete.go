@@ -4,7 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/tczekajlo/kir/etcd"
+	"github.com/tczekajlo/kir/store"
 )
 
 // deleteCmd represents the delete command
@@ -16,16 +16,20 @@ var deleteCmd = &cobra.Command{
 			fmt.Println("You have to give a name of rule to delete")
 			return
 		}
-		etcd := etcd.Client{}
-		etcd.New()
-		err := etcd.Delete(args[0])
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		deleted, err := storage.Delete(store.Rule, args[0])
 		if err != nil {
 			fmt.Println("Cannot delete rule:", err)
 			return
 		}
-		defer etcd.Client.Close()
 
-		fmt.Println("Deleted rules:", etcd.DeleteResponse.Deleted)
+		fmt.Println("Deleted rules:", deleted)
 
 	},
 }
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/policy"
+	"github.com/tczekajlo/kir/store"
+)
+
+const editHeader = `# Edit the rule below and close the editor to save it. The rule is saved only
+# if nobody changed it since resource_version, otherwise the changes are kept in this file.
+# Lines starting with '#' are ignored, an unchanged file cancels the edit.
+#
+`
+
+// editCmd represents the edit command
+var editCmd = &cobra.Command{
+	Use:   "edit RULE",
+	Short: "Edits a rule in an editor",
+	Long: `Opens YAML of a rule in the editor given by EDITOR environment variable (vi by default)
+and saves the rule when the editor is closed. The rule is saved only if nobody changed it
+since it was opened. Otherwise, the changes are kept in a temporary file and the rule
+has to be edited again.
+
+For example:
+
+kir edit banned
+EDITOR=nano kir edit banned
+
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println("You have to give a name of rule to edit")
+			return
+		}
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		data, err := store.GetRule(storage, args[0])
+		if err != nil {
+			fmt.Println("Cannot get rule:", err)
+			return
+		}
+
+		out, err := yaml.Marshal(data)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		original := append([]byte(editHeader), out...)
+
+		file, err := ioutil.TempFile("", "kir-edit-*.yaml")
+		if err != nil {
+			fmt.Println("Cannot create temporary file:", err)
+			return
+		}
+		path := file.Name()
+
+		_, err = file.Write(original)
+		file.Close()
+		if err != nil {
+			os.Remove(path)
+			fmt.Println("Cannot write temporary file:", err)
+			return
+		}
+
+		edited, err := editFile(path)
+		if err != nil {
+			fmt.Printf("%s, the rule is kept in %s\n", err, path)
+			return
+		}
+
+		if bytes.Equal(edited, original) {
+			os.Remove(path)
+			fmt.Println("Edit cancelled, no changes made.")
+			return
+		}
+
+		rule := &pb.Rule{}
+		err = yaml.Unmarshal(edited, rule)
+		if err != nil {
+			fmt.Printf("Cannot parse rule: %v, the changes are kept in %s\n", err, path)
+			return
+		}
+
+		if rule.Name != data.Name {
+			fmt.Printf("Name of rule cannot be changed, the changes are kept in %s\n", path)
+			return
+		}
+
+		err = policy.Validate(rule)
+		if err != nil {
+			fmt.Printf("%s, the changes are kept in %s\n", err, path)
+			return
+		}
+
+		// the rule is saved only if it is still the version which was edited
+		rule.ResourceVersion = data.ResourceVersion
+		updated, err := storage.Add(store.Rule, rule.Name, rule, true)
+		if err != nil {
+			fmt.Printf("Cannot save rule, the changes are kept in %s\n", path)
+			return
+		}
+
+		if !updated {
+			fmt.Printf("Rule \"%s\" was changed or deleted by someone else since it was opened. Your changes are not saved, they are kept in %s. Run kir edit again to edit the current version of rule.\n", rule.Name, path)
+			return
+		}
+
+		os.Remove(path)
+		fmt.Printf("Rule \"%s\" edited.\n", rule.Name)
+	},
+}
+
+// editFile opens the file in the editor given by EDITOR environment variable
+// and returns content of the file when the editor is closed
+func editFile(path string) ([]byte, error) {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+
+	command := exec.Command(editor[0], append(editor[1:], path)...)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+
+	if err := command.Run(); err != nil {
+		return nil, fmt.Errorf("Editor %s failed: %s", editor[0], err)
+	}
+
+	return ioutil.ReadFile(path)
+}
+
+func init() {
+	RootCmd.AddCommand(editCmd)
+}
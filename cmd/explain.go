@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/kube"
+	"github.com/tczekajlo/kir/policy"
+	"github.com/tczekajlo/kir/types"
+)
+
+// explainCmd represents the explain command
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Explains why an image review request is allowed or denied",
+	Long: `Makes image review of the request from a file and shows results of every rule
+for every container and which rule produced the final decision.
+For example:
+
+kir explain -f testdata/request.json
+
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if cmd.Flag("file").Value.String() == "" {
+			fmt.Println("A file with request is not given. Use --file flag")
+			return
+		}
+
+		fileData, err := ioutil.ReadFile(cmd.Flag("file").Value.String())
+		if err != nil {
+			fmt.Printf("err: %v\n", err)
+			return
+		}
+
+		req := &types.ImageReview{}
+		err = yaml.Unmarshal(fileData, req)
+		if err != nil {
+			fmt.Printf("err: %v\n", err)
+			return
+		}
+
+		if viper.GetBool("server.namespace_labels.enabled") {
+			client, err := kube.NewClient()
+			if err != nil {
+				fmt.Printf("err: %v\n", err)
+				return
+			}
+			policy.SetNamespaceLabels(&kube.NamespaceClient{Client: client})
+		}
+
+		response := policy.Explain(req)
+
+		//print output
+		if cmd.Flag("output").Value.String() != "" {
+			printOutput(response, cmd.Flag("output").Value.String())
+			return
+		}
+
+		printTrace(response.Status)
+	},
+}
+
+func printTrace(status types.ImageReviewStatus) {
+	// there is no trace in the case when rules cannot be loaded,
+	// the request is decided by the failure policy
+	if status.Trace == nil {
+		fmt.Printf("Request allowed: %t\n", status.Allowed)
+		if status.Reason != "" {
+			fmt.Printf("Reason: %s\n", status.Reason)
+		}
+		if failure := status.AuditAnnotations[policy.FailureAnnotation]; failure != "" {
+			fmt.Printf("Reason: %s\n", failure)
+		}
+		return
+	}
+
+	fmt.Printf("Conflict strategy: %s\n", status.Trace.Strategy)
+
+	if len(status.Trace.Sets) != 0 {
+		fmt.Printf("Bound sets: %s\n", strings.Join(status.Trace.Sets, ", "))
+	}
+
+	for _, err := range status.Trace.Errors {
+		fmt.Printf("Error: %s, it is left out of review\n", err)
+	}
+
+	for _, container := range status.Trace.Containers {
+		fmt.Printf("\nImage: %s\n", container.Image)
+		fmt.Printf("Decided by: %s\n", container.DecidedBy)
+		fmt.Printf("Allowed: %t\n", container.Allowed)
+		if container.Reason != "" {
+			fmt.Printf("Reason: %s\n", container.Reason)
+		}
+		fmt.Println()
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Rule", "Priority", "Mode", "Active", "Image", "Namespace", "Annotations", "Condition", "Matched", "Allowed"})
+		table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+		table.SetCenterSeparator(" ")
+		table.SetColumnSeparator(" ")
+
+		for _, rule := range container.Rules {
+			image := strconv.FormatBool(rule.ImageMatched)
+			if rule.ImageExcluded {
+				image = "excluded"
+			}
+
+			allowed := ""
+			if rule.Matched {
+				allowed = strconv.FormatBool(rule.Allowed)
+			}
+
+			table.Append([]string{rule.Name,
+				strconv.FormatInt(int64(rule.Priority), 10),
+				rule.Mode,
+				strconv.FormatBool(rule.Active),
+				image,
+				strconv.FormatBool(rule.NamespaceMatched),
+				strconv.FormatBool(rule.AnnotationsMatched),
+				strconv.FormatBool(rule.ConditionMatched),
+				strconv.FormatBool(rule.Matched),
+				allowed,
+			})
+		}
+		table.Render()
+	}
+
+	if len(status.Trace.RegoDenies) != 0 {
+		fmt.Println("\nDenied by Rego policies:")
+		for _, message := range status.Trace.RegoDenies {
+			fmt.Printf("  %s\n", message)
+		}
+	}
+
+	if status.Trace.BreakGlass != "" {
+		fmt.Printf("\nDenies overridden by break-glass ticket %s\n", status.Trace.BreakGlass)
+	}
+
+	fmt.Printf("\nRequest allowed: %t\n", status.Allowed)
+	if status.Reason != "" {
+		fmt.Printf("Reason: %s\n", status.Reason)
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(explainCmd)
+
+	explainCmd.Flags().StringP("file", "f", "", "a file with image review request (JSON or YAML)")
+	explainCmd.Flags().StringP("output", "o", "", "set the output format (yaml)")
+}
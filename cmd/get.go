@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -11,19 +12,22 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"github.com/tczekajlo/kir/config"
-	"github.com/tczekajlo/kir/etcd"
 	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/policy"
+	"github.com/tczekajlo/kir/store"
 )
 
 func getAllRules(cmd *cobra.Command, args []string) {
-	var err error
 	var data *pb.RulesList
 	var dataRule *pb.Rule
-	var limit int64
+	var limit, count int64
 
-	// etcd
-	etcd := etcd.Client{}
-	etcd.New()
+	storage, err := store.New()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer storage.Close()
 
 	if len(args) == 0 {
 		limit = config.EtcdGetLimit
@@ -31,9 +35,9 @@ func getAllRules(cmd *cobra.Command, args []string) {
 			limit = 0
 		}
 
-		data, err = etcd.GetAll(limit)
+		data, count, err = store.GetRules(storage, limit)
 	} else {
-		dataRule, err = etcd.Get("rule/" + args[0])
+		dataRule, err = store.GetRule(storage, args[0])
 		data = &pb.RulesList{}
 		data.Rule = append(data.Rule, dataRule)
 
@@ -42,7 +46,6 @@ func getAllRules(cmd *cobra.Command, args []string) {
 		fmt.Println("Cannot get rule(s):", err)
 		return
 	}
-	defer etcd.Client.Close()
 
 	//print output
 	if len(args) != 0 && cmd.Flag("output").Value.String() != "" {
@@ -51,7 +54,7 @@ func getAllRules(cmd *cobra.Command, args []string) {
 	}
 
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Name", "Namespace", "Image", "Annotations", "Allowed"})
+	table.SetHeader([]string{"Name", "Namespace", "Image", "Annotations", "Allowed", "Priority", "Mode"})
 	table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
 	table.SetCenterSeparator(" ")
 	table.SetColumnSeparator(" ")
@@ -61,23 +64,37 @@ func getAllRules(cmd *cobra.Command, args []string) {
 		rule = ruleFillDefault(rule)
 
 		for _, container := range rule.Containers {
-			image = append(image, container.Image)
+			image = append(image, containerToString(container))
 		}
+		for _, container := range rule.ExcludeImages {
+			image = append(image, "!"+containerToString(container))
+		}
+
+		namespace := []string{rule.Namespace}
+		if rule.NamespaceSelector != "" {
+			namespace = append(namespace, "labels: "+rule.NamespaceSelector)
+		}
+		for _, excluded := range rule.ExcludeNamespaces {
+			namespace = append(namespace, "!"+excluded)
+		}
+
 		table.Append([]string{rule.Name,
-			rule.Namespace,
+			withMatchType(strings.Join(namespace, "\n"), rule.NamespaceMatchType),
 			strings.Join(image, "\n"),
-			strings.Join(annotationsToString(rule.Annotations), "\n"),
+			withMatchType(strings.Join(ruleAnnotationsToString(rule), "\n"), rule.AnnotationsMatchType),
 			strconv.FormatBool(rule.Allowed),
+			strconv.FormatInt(int64(rule.Priority), 10),
+			rule.Mode,
 		})
 	}
 	table.Render()
 
-	if etcd.GetResponse.Count > limit && limit != 0 {
+	if count > limit && limit != 0 {
 		fmt.Println("Showed results are limited. In order to show all results use --show-all flag.")
 	}
 }
 
-func printOutput(data *pb.Rule, format string) {
+func printOutput(data interface{}, format string) {
 	switch format {
 	case "yaml":
 		output, err := yaml.Marshal(data)
@@ -90,22 +107,84 @@ func printOutput(data *pb.Rule, format string) {
 	}
 }
 
+func containerToString(container *pb.Rule_Containers) string {
+	var result []string
+
+	if container.Image != "" {
+		result = append(result, container.Image)
+	}
+
+	parts := [][2]string{
+		{"registry", container.Registry},
+		{"repository", container.Repository},
+		{"tag", container.Tag},
+		{"digest", container.Digest},
+		{"semver", container.Semver},
+	}
+	for _, part := range parts {
+		if part[1] != "" {
+			result = append(result, fmt.Sprintf("%s=%s", part[0], part[1]))
+		}
+	}
+
+	return withMatchType(strings.Join(result, " "), container.MatchType)
+}
+
+// withMatchType adds match type to pattern in the case when it is set
+func withMatchType(pattern, matchType string) string {
+	if matchType == "" {
+		return pattern
+	}
+
+	return fmt.Sprintf("%s (%s)", pattern, matchType)
+}
+
+// ruleAnnotationsToString returns annotations of the rule, forbidden annotations
+// are prefixed with "!"
+func ruleAnnotationsToString(rule *pb.Rule) []string {
+	var result []string
+
+	if rule.AnnotationsMatch == policy.AnnotationsMatchAll {
+		result = append(result, "all of:")
+	}
+
+	result = append(result, annotationsToString(rule.Annotations)...)
+	for _, annotation := range annotationsToString(rule.ForbiddenAnnotations) {
+		result = append(result, "!"+annotation)
+	}
+
+	return result
+}
+
 func annotationsToString(annotations map[string]string) []string {
 	var result []string
 
 	for key, value := range annotations {
+		if value == "" {
+			result = append(result, key)
+			continue
+		}
 		result = append(result, fmt.Sprintf("%s=%s", key, value))
 	}
+	sort.Strings(result)
 
 	return result
 }
 
 func ruleFillDefault(data *pb.Rule) *pb.Rule {
-	if data.Annotations == nil {
+	if data.Annotations == nil && data.ForbiddenAnnotations == nil {
 		data.Annotations = make(map[string]string)
 		data.Annotations["<none>"] = "<none>"
 	}
 
+	if data.Mode == "" {
+		data.Mode = policy.ModeEnforce
+	}
+
+	if data.Namespace == "" {
+		data.Namespace = "<all>"
+	}
+
 	return data
 }
 
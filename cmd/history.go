@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/store"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history RULE",
+	Short: "Shows history of a rule",
+	Long: `Shows every version of a rule together with time of change and differences
+against the previous version. Every change made by kir commands is kept in history,
+also deletion of rule. A version can be restored by kir rollback command.
+
+For example:
+
+kir history banned
+kir history banned --diff=false
+
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println("You have to give a name of rule")
+			return
+		}
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		historyStore, ok := storage.(store.HistoryStore)
+		if !ok {
+			fmt.Printf("Store %s does not keep history of rules\n", viper.GetString("store.backend"))
+			return
+		}
+
+		versions, err := historyStore.History(store.Rule, args[0])
+		if err != nil {
+			fmt.Println("Cannot get history of rule:", err)
+			return
+		}
+
+		if len(versions) == 0 {
+			fmt.Printf("Rule \"%s\" has no history.\n", args[0])
+			return
+		}
+
+		//print output
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Revision", "Time", "Change"})
+		table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+		table.SetCenterSeparator(" ")
+		table.SetColumnSeparator(" ")
+
+		for i, version := range versions {
+			table.Append([]string{
+				strconv.FormatInt(version.Revision, 10),
+				version.Timestamp.Format(time.RFC3339),
+				versionChange(versions, i),
+			})
+		}
+		table.Render()
+
+		if diff, _ := cmd.Flags().GetBool("diff"); !diff {
+			return
+		}
+
+		for i, version := range versions {
+			var previous *store.Version
+			if i > 0 {
+				previous = versions[i-1]
+			}
+
+			out, err := versionDiff(previous, version)
+			if err != nil {
+				fmt.Println("Cannot show differences:", err)
+				return
+			}
+
+			fmt.Printf("\nRevision %d, %s\n%s", version.Revision, versionChange(versions, i), out)
+		}
+	},
+}
+
+// versionChange describes the change which leads to the version
+func versionChange(versions []*store.Version, i int) string {
+	switch {
+	case versions[i].Deleted:
+		return "deleted"
+	case i == 0 || versions[i-1].Deleted:
+		return "created"
+	default:
+		return "updated"
+	}
+}
+
+// versionDiff returns unified diff of YAML of the version against the previous one,
+// previous is nil for the first version
+func versionDiff(previous, version *store.Version) (string, error) {
+	var from, to string
+	var err error
+
+	fromFile := "none"
+	if previous != nil {
+		fromFile = fmt.Sprintf("revision %d", previous.Revision)
+		if from, err = versionYAML(previous); err != nil {
+			return "", err
+		}
+	}
+	if to, err = versionYAML(version); err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(from),
+		B:        splitLines(to),
+		FromFile: fromFile,
+		ToFile:   fmt.Sprintf("revision %d", version.Revision),
+		Context:  3,
+	})
+}
+
+// splitLines splits text into lines which keep their line endings
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// versionYAML returns YAML of the version, it is empty for deleted object
+func versionYAML(version *store.Version) (string, error) {
+	if version.Deleted {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(version.Data)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func init() {
+	RootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().Bool("diff", true, "show differences between versions")
+}
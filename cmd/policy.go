@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/policy"
+	"github.com/tczekajlo/kir/store"
+)
+
+// policyCmd represents the policy command
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Manages Rego policies",
+	Long: `Manages Rego policies which are evaluated during image's review together with rules.
+Policies have to be defined in kir package, the ImageReview request is available as input,
+and every message of deny set denies the request regardless of rules.
+For example:
+
+# Adds policy from the file
+kir policy add --name no_latest -f no_latest.rego
+
+`,
+}
+
+var policyAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Adds a new Rego policy",
+	Run: func(cmd *cobra.Command, args []string) {
+		name := cmd.Flag("name").Value.String()
+		if name == "" {
+			fmt.Println("Policy name is empty. Use --name flag")
+			return
+		}
+
+		if cmd.Flag("file").Value.String() == "" {
+			fmt.Println("A file with Rego module is not given. Use --file flag")
+			return
+		}
+
+		module, err := ioutil.ReadFile(cmd.Flag("file").Value.String())
+		if err != nil {
+			fmt.Printf("err: %v\n", err)
+			return
+		}
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		// policies are evaluated together, thus the policy has to compile with stored ones
+		stored, err := store.GetPolicies(storage)
+		if err != nil {
+			fmt.Println("Cannot get policies:", err)
+			return
+		}
+
+		data := &pb.Policy{Name: name, Module: string(module)}
+		err = policy.ValidatePolicy(data, stored.Policy)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		override, _ := cmd.Flags().GetBool("override")
+		added, err := storage.Add(store.Policy, data.Name, data, override)
+		if err != nil {
+			fmt.Println("Cannot add policy")
+			return
+		}
+
+		if added {
+			fmt.Printf("Policy \"%s\" added.\n", data.Name)
+		} else {
+			fmt.Printf("Policy \"%s\" already exists.\n", data.Name)
+		}
+	},
+}
+
+var policyGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Gets the Rego policy or all Rego policies",
+	Long: `Gets all Rego policies or Rego module of the given policy.
+For example:
+
+kir policy get no_latest
+
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		if len(args) != 0 {
+			data, err := store.GetPolicy(storage, args[0])
+			if err != nil {
+				fmt.Println("Cannot get policy:", err)
+				return
+			}
+
+			//print output
+			if cmd.Flag("output").Value.String() != "" {
+				printOutput(data, cmd.Flag("output").Value.String())
+				return
+			}
+
+			fmt.Print(data.Module)
+			return
+		}
+
+		data, err := store.GetPolicies(storage)
+		if err != nil {
+			fmt.Println("Cannot get policies:", err)
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Lines"})
+		table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+		table.SetCenterSeparator(" ")
+		table.SetColumnSeparator(" ")
+
+		for _, data := range data.Policy {
+			table.Append([]string{data.Name,
+				fmt.Sprintf("%d", strings.Count(strings.TrimRight(data.Module, "\n"), "\n")+1),
+			})
+		}
+		table.Render()
+	},
+}
+
+var policyDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Deletes a Rego policy",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println("You have to give a name of policy to delete")
+			return
+		}
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		deleted, err := storage.Delete(store.Policy, args[0])
+		if err != nil {
+			fmt.Println("Cannot delete policy:", err)
+			return
+		}
+
+		fmt.Println("Deleted policies:", deleted)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(policyCmd)
+	policyCmd.AddCommand(policyAddCmd)
+	policyCmd.AddCommand(policyGetCmd)
+	policyCmd.AddCommand(policyDeleteCmd)
+
+	policyAddCmd.Flags().String("name", "", "policy name")
+	policyAddCmd.Flags().StringP("file", "f", "", "a file with Rego module")
+	policyAddCmd.Flags().Bool("override", false, "override existing policy")
+
+	policyGetCmd.Flags().StringP("output", "o", "", "set the output format (yaml)")
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/store"
+)
+
+// rollbackCmd represents the rollback command
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback RULE",
+	Short: "Restores an earlier version of a rule",
+	Long: `Restores the version of a rule from the given revision, revisions are shown
+by kir history command. The rule is restored only if nobody changed it in the meantime,
+also a deleted rule can be restored. The rollback itself is kept in history as well.
+
+For example:
+
+kir rollback banned --to 1040
+
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println("You have to give a name of rule")
+			return
+		}
+
+		revision, _ := cmd.Flags().GetInt64("to")
+		if revision <= 0 {
+			fmt.Println("You have to give a revision of rule. Use --to flag")
+			return
+		}
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		historyStore, ok := storage.(store.HistoryStore)
+		if !ok {
+			fmt.Printf("Store %s does not keep history of rules\n", viper.GetString("store.backend"))
+			return
+		}
+
+		restored, err := historyStore.Rollback(store.Rule, args[0], revision)
+		if err != nil {
+			fmt.Println("Cannot roll back rule:", err)
+			return
+		}
+
+		if restored {
+			fmt.Printf("Rule \"%s\" restored to revision %d.\n", args[0], revision)
+		} else {
+			fmt.Printf("Rule \"%s\" was changed in the meantime, check kir history and try again.\n", args[0])
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(rollbackCmd)
+
+	rollbackCmd.Flags().Int64("to", 0, "revision of rule to restore")
+}
@@ -31,6 +31,9 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	RootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.kir.yaml)")
+	RootCmd.PersistentFlags().String("store", "etcd", "backend of store of rules (etcd, memory or yaml)")
+	RootCmd.PersistentFlags().String("store-dir", "", "a directory of YAML files for yaml store")
+	RootCmd.PersistentFlags().String("store-seed", "", "a YAML file or a directory with rules which memory store is filled with at start")
 	RootCmd.PersistentFlags().StringSlice(config.EtcdEndpoints, []string{"http://localhost:2379"}, "list of URLs to etcd endpoint")
 	RootCmd.PersistentFlags().String("etcd-prefix", "/kir/", "etcd prefix")
 	RootCmd.PersistentFlags().Duration("etcd-dial-timeout", 2*time.Second, "dial timeout for client connections")
@@ -39,8 +42,11 @@ func init() {
 	RootCmd.PersistentFlags().String("etcd-key", "", "identify secure client using this TLS key file")
 	RootCmd.PersistentFlags().String("etcd-user", "", "username[:password] for authentication (prompt if password is not supplied)")
 	RootCmd.PersistentFlags().Duration("etcd-command-timeout", 5*time.Second, "timeout for short running command (excluding dial timeout)")
+	RootCmd.PersistentFlags().Int("etcd-max-txn-ops", 128, "maximum number of operations in a transaction, it cannot exceed --max-txn-ops of etcd")
+	RootCmd.PersistentFlags().Int("etcd-history-limit", 50, "number of versions kept in history of every rule, 0 keeps all of them")
 	RootCmd.PersistentFlags().Bool("etcd-insecure-skip-tls-verify", false, "skip server certificate verification")
 	RootCmd.PersistentFlags().Bool("etcd-insecure-transport", true, "disable transport security for client connections")
+	RootCmd.PersistentFlags().String("kubeconfig", "", "a path to the kubeconfig file used to get labels of namespaces (default in-cluster configuration)")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -59,6 +65,9 @@ func initConfig() {
 	viper.AutomaticEnv()                   // read in environment variables that match
 
 	// default values
+	viper.BindPFlag("store.backend", RootCmd.Flags().Lookup("store"))
+	viper.BindPFlag("store.yaml.dir", RootCmd.Flags().Lookup("store-dir"))
+	viper.BindPFlag("store.memory.seed", RootCmd.Flags().Lookup("store-seed"))
 	viper.BindPFlag("etcd.endpoints", RootCmd.Flags().Lookup(config.EtcdEndpoints))
 	viper.BindPFlag("etcd.prefix", RootCmd.Flags().Lookup(config.EtcdPrefix))
 	viper.BindPFlag("etcd.dial_timeout", RootCmd.Flags().Lookup("etcd-dial-timeout"))
@@ -67,12 +76,19 @@ func initConfig() {
 	viper.BindPFlag("etcd.key", RootCmd.Flags().Lookup("etcd-key"))
 	viper.BindPFlag("etcd.user", RootCmd.Flags().Lookup("etcd-user"))
 	viper.BindPFlag("etcd.command_timeout", RootCmd.Flags().Lookup("etcd-command-timeout"))
+	viper.BindPFlag("etcd.max_txn_ops", RootCmd.Flags().Lookup("etcd-max-txn-ops"))
+	viper.BindPFlag("etcd.history_limit", RootCmd.Flags().Lookup("etcd-history-limit"))
 	viper.BindPFlag("etcd.insecure_skip_tls_verify", RootCmd.Flags().Lookup("etcd-insecure-skip-tls-verify"))
 	viper.BindPFlag("etcd.insecure_transport", RootCmd.Flags().Lookup("etcd-insecure-transport"))
+	viper.BindPFlag("kubernetes.kubeconfig", RootCmd.Flags().Lookup("kubeconfig"))
 
 	err := viper.ReadInConfig()
 	if err != nil {
 		log.Fatalln(err)
 	}
 
+	// memory store starts empty in every process
+	if err := seedStore(viper.GetString("store.memory.seed")); err != nil {
+		log.Fatalln(err)
+	}
 }
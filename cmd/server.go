@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"time"
 
 	"github.com/fvbock/endless"
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	apiv1 "github.com/tczekajlo/kir/api/v1"
+	"github.com/tczekajlo/kir/kube"
+	"github.com/tczekajlo/kir/policy"
+	"github.com/tczekajlo/kir/store"
 	"github.com/tczekajlo/kir/utils"
 )
 
@@ -24,6 +28,45 @@ var serverCmd = &cobra.Command{
 
 		fmt.Printf("%s\n\n", utils.Banner)
 
+		err = policy.ValidateStrategy(viper.GetString("server.conflict_strategy"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = policy.ValidateFailurePolicy(viper.GetString("server.failure_policy"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if viper.GetDuration("server.cache.resync") <= 0 {
+			log.Fatal("Resync period of cache of rules has to be greater than 0")
+		}
+
+		// rules are loaded once and kept up to date by the cache, the store is shared
+		// by all requests. In the case when the store cannot be reached, the last-known-good
+		// snapshot is used and requests are reviewed according to the failure policy
+		// if there are no rules at all.
+		rules := policy.NewCache(store.New, viper.GetDuration("server.cache.resync"), viper.GetString("server.snapshot_file"))
+		rules.Run(make(chan struct{}))
+		policy.SetCache(rules)
+
+		// labels of namespaces for namespace selectors of rules
+		if viper.GetBool("server.namespace_labels.enabled") {
+			client, err := kube.NewClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			namespaces := kube.NewNamespaceCache(client, viper.GetDuration("server.namespace_labels.resync"))
+			// the server does not wait for the Kubernetes API forever, rules with namespace
+			// selectors do not match until the cache is synced
+			err = namespaces.Run(make(chan struct{}), viper.GetDuration("server.namespace_labels.sync_timeout"))
+			if err != nil {
+				log.Printf("%s, rules with namespace selectors do not match until it is synced\n", err)
+			}
+			policy.SetNamespaceLabels(namespaces)
+		}
+
 		// HTTP server
 		gin.SetMode(gin.ReleaseMode)
 
@@ -81,6 +124,19 @@ func init() {
 	serverCmd.Flags().String("tls-key-file", "key.pem", "a path to the key file")
 	serverCmd.Flags().String("tls-cacert-file", "", "a path to the root CA file")
 	serverCmd.Flags().Bool("tls-require-and-verify-client-cert", false, "turns on client authentication for this listener")
+	serverCmd.Flags().Bool("default-allowed", false, "decision for images which do not match to any rule")
+	serverCmd.Flags().String("default-reason", policy.DefaultReason, "reason of the decision for images which do not match to any rule")
+	serverCmd.Flags().Bool("break-glass-enabled", false, "enables overriding of denies by break-glass annotation")
+	serverCmd.Flags().String("break-glass-annotation", policy.DefaultBreakGlassAnnotation, "key of annotation which contains ticket ID of emergency deploy")
+	serverCmd.Flags().String("break-glass-ticket-pattern", policy.DefaultBreakGlassTicketPattern, "regex which ticket ID has to match to")
+	serverCmd.Flags().StringSlice("break-glass-namespaces", []string{}, "list of namespace regexes in which break-glass is enabled (default all namespaces)")
+	serverCmd.Flags().Bool("namespace-labels-enabled", false, "enables namespace selectors of rules, labels of namespaces are cached from the Kubernetes API")
+	serverCmd.Flags().Duration("namespace-labels-resync", 10*time.Minute, "resync period of cache of namespaces")
+	serverCmd.Flags().Duration("namespace-labels-sync-timeout", 30*time.Second, "how long the server waits for cache of namespaces at start")
+	serverCmd.Flags().String("failure-policy", policy.FailClosed, "decision for requests which cannot be reviewed, e.g. rules are not available (fail-closed, fail-open)")
+	serverCmd.Flags().String("snapshot-file", "/var/lib/kir/snapshot.json", "a path to the last-known-good snapshot of rules, which is used when the store cannot be reached at startup (empty disables it)")
+	serverCmd.Flags().Duration("cache-resync", 30*time.Second, "period of checks of connection to the store, stores which cannot be watched are loaded again")
+	serverCmd.Flags().String("conflict-strategy", policy.FirstMatch, "decision to take when several rules match to an image (first-match, deny-overrides, allow-overrides)")
 
 	// viper
 	viper.BindPFlag("server.listen", serverCmd.Flags().Lookup("listen"))
@@ -89,5 +145,18 @@ func init() {
 	viper.BindPFlag("server.tls.cert_file", serverCmd.Flags().Lookup("tls-cert-file"))
 	viper.BindPFlag("server.tls.key_file", serverCmd.Flags().Lookup("tls-key-file"))
 	viper.BindPFlag("server.tls.cacert_file", serverCmd.Flags().Lookup("tls-cacert-file"))
+	viper.BindPFlag("server.conflict_strategy", serverCmd.Flags().Lookup("conflict-strategy"))
+	viper.BindPFlag("server.break_glass.enabled", serverCmd.Flags().Lookup("break-glass-enabled"))
+	viper.BindPFlag("server.break_glass.annotation", serverCmd.Flags().Lookup("break-glass-annotation"))
+	viper.BindPFlag("server.break_glass.ticket_pattern", serverCmd.Flags().Lookup("break-glass-ticket-pattern"))
+	viper.BindPFlag("server.break_glass.namespaces", serverCmd.Flags().Lookup("break-glass-namespaces"))
+	viper.BindPFlag("server.namespace_labels.enabled", serverCmd.Flags().Lookup("namespace-labels-enabled"))
+	viper.BindPFlag("server.namespace_labels.resync", serverCmd.Flags().Lookup("namespace-labels-resync"))
+	viper.BindPFlag("server.namespace_labels.sync_timeout", serverCmd.Flags().Lookup("namespace-labels-sync-timeout"))
+	viper.BindPFlag("server.failure_policy", serverCmd.Flags().Lookup("failure-policy"))
+	viper.BindPFlag("server.snapshot_file", serverCmd.Flags().Lookup("snapshot-file"))
+	viper.BindPFlag("server.cache.resync", serverCmd.Flags().Lookup("cache-resync"))
+	viper.BindPFlag("server.default_allowed", serverCmd.Flags().Lookup("default-allowed"))
+	viper.BindPFlag("server.default_reason", serverCmd.Flags().Lookup("default-reason"))
 	viper.BindPFlag("server.tls.require_and_verify_client_cert", serverCmd.Flags().Lookup("tls-require-and-verify-client-cert"))
 }
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/policy"
+	"github.com/tczekajlo/kir/store"
+)
+
+var policySet pb.PolicySet
+
+// setCmd represents the set command
+var setCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Manages policy sets",
+	Long: `Manages named policy sets which group rules. Sets are bound to namespaces
+by bindings (see kir bind), and rules of bound sets are evaluated in binding order.
+For example:
+
+# Groups rules which are used within production namespaces
+kir set add --name production --rules pinned,trusted_registries
+
+`,
+}
+
+var setAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Adds a new policy set",
+	Run: func(cmd *cobra.Command, args []string) {
+		if policySet.Name == "" {
+			fmt.Println("Set name is empty. Use --name flag")
+			return
+		}
+
+		err := policy.ValidateSet(&policySet)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		override, _ := cmd.Flags().GetBool("override")
+		added, err := storage.Add(store.Set, policySet.Name, &policySet, override)
+		if err != nil {
+			fmt.Println("Cannot add set")
+			return
+		}
+
+		if added {
+			fmt.Printf("Set \"%s\" added.\n", policySet.Name)
+		} else {
+			fmt.Printf("Set \"%s\" already exists.\n", policySet.Name)
+		}
+	},
+}
+
+var setGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Gets the policy set or all policy sets",
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		var data *pb.PolicySetsList
+		var dataSet *pb.PolicySet
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		if len(args) == 0 {
+			data, err = store.GetSets(storage)
+		} else {
+			dataSet, err = store.GetSet(storage, args[0])
+			data = &pb.PolicySetsList{}
+			data.Set = append(data.Set, dataSet)
+		}
+		if err != nil {
+			fmt.Println("Cannot get set(s):", err)
+			return
+		}
+
+		//print output
+		if len(args) != 0 && cmd.Flag("output").Value.String() != "" {
+			printOutput(dataSet, cmd.Flag("output").Value.String())
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Rules"})
+		table.SetBorders(tablewriter.Border{Left: false, Top: false, Right: false, Bottom: false})
+		table.SetCenterSeparator(" ")
+		table.SetColumnSeparator(" ")
+
+		for _, data := range data.Set {
+			table.Append([]string{data.Name, strings.Join(data.Rules, "\n")})
+		}
+		table.Render()
+	},
+}
+
+var setDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Deletes a policy set",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			fmt.Println("You have to give a name of set to delete")
+			return
+		}
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		deleted, err := storage.Delete(store.Set, args[0])
+		if err != nil {
+			fmt.Println("Cannot delete set:", err)
+			return
+		}
+
+		fmt.Println("Deleted sets:", deleted)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(setCmd)
+	setCmd.AddCommand(setAddCmd)
+	setCmd.AddCommand(setGetCmd)
+	setCmd.AddCommand(setDeleteCmd)
+
+	setAddCmd.Flags().StringVar(&policySet.Name, "name", "", "set name")
+	setAddCmd.Flags().StringSliceVar(&policySet.Rules, "rules", []string{}, "names of rules in order of evaluation (items in a list should be separated by a comma)")
+	setAddCmd.Flags().Bool("override", false, "override existing set")
+
+	setGetCmd.Flags().StringP("output", "o", "", "set the output format (yaml)")
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tczekajlo/kir/policy"
+	"github.com/tczekajlo/kir/store"
+)
+
+// setModeCmd represents the set-mode command
+var setModeCmd = &cobra.Command{
+	Use:   "set-mode RULE MODE",
+	Short: "Sets enforcement mode of a rule",
+	Long: `Sets enforcement mode of a rule without changing the rest of rule.
+Supported modes:
+
+enforce - decision of the rule is taken into account
+warn    - decision of the rule is not taken into account, a would-be deny is reported
+          in audit annotations of the response and in the server log
+audit   - decision of the rule is not taken into account, a would-be decision is recorded
+          in audit annotations of the response
+
+For example:
+
+kir set-mode banned warn
+
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			fmt.Println("You have to give a name of rule and a mode")
+			return
+		}
+
+		err := policy.ValidateMode(args[1])
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		storage, err := store.New()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer storage.Close()
+
+		data, err := store.GetRule(storage, args[0])
+		if err != nil {
+			fmt.Println("Cannot get rule:", err)
+			return
+		}
+
+		data.Mode = args[1]
+		updated, err := storage.Add(store.Rule, data.Name, data, true)
+		if err != nil {
+			fmt.Println("Cannot set mode of rule")
+			return
+		}
+
+		if updated {
+			fmt.Printf("Mode of rule \"%s\" set to %s.\n", data.Name, data.Mode)
+		} else {
+			fmt.Printf("Rule \"%s\" was changed or deleted in the meantime, try again.\n", data.Name)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(setModeCmd)
+}
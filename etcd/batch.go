@@ -0,0 +1,69 @@
+package etcd
+
+import (
+	"fmt"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/viper"
+	"golang.org/x/net/context"
+)
+
+// Change is a change of object made by Commit
+type Change struct {
+	Kind string
+	Name string
+
+	// Data is the object to store, nil deletes the object
+	Data proto.Message
+
+	// ModRevision is mod revision of the key which the object has to have,
+	// 0 means that the object cannot exist
+	ModRevision int64
+}
+
+// Commit makes all changes in one transaction, which succeeds only if none of objects
+// is changed since its mod revision was read. Versions of objects are written to history
+// in the same transaction, and at most one of the oldest versions of every object is deleted
+// from history above the limit. The result of transaction is available in TxnResponse.
+func (c *Client) Commit(changes []*Change) error {
+	var cmps []clientv3.Cmp
+	var ops []clientv3.Op
+
+	for _, change := range changes {
+		key := change.Kind + "/" + change.Name
+		cmps = append(cmps, clientv3.Compare(clientv3.ModRevision(key), "=", change.ModRevision))
+
+		if change.Data == nil {
+			history, err := c.historyOps(change.Kind, change.Name, nil, true, 1)
+			if err != nil {
+				return err
+			}
+			ops = append(append(ops, clientv3.OpDelete(key)), history...)
+			continue
+		}
+
+		out, err := proto.Marshal(change.Data)
+		if err != nil {
+			return fmt.Errorf("Failed to encode %s: %s", change.Kind, err)
+		}
+
+		history, err := c.historyOps(change.Kind, change.Name, out, false, 1)
+		if err != nil {
+			return err
+		}
+		// an updated object keeps its lease, e.g. a rule added with ttl
+		var opts []clientv3.OpOption
+		if change.ModRevision != 0 {
+			opts = append(opts, clientv3.WithIgnoreLease())
+		}
+		ops = append(append(ops, clientv3.OpPut(key, string(out), opts...)), history...)
+	}
+
+	var err error
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("etcd.command_timeout"))
+	c.TxnResponse, err = c.Client.Txn(ctx).If(cmps...).Then(ops...).Commit()
+	cancel()
+
+	return err
+}
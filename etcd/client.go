@@ -14,7 +14,6 @@ import (
 	"github.com/coreos/pkg/capnslog"
 	"github.com/golang/protobuf/proto"
 	"github.com/spf13/viper"
-	"github.com/tczekajlo/kir/pb"
 	"golang.org/x/net/context"
 )
 
@@ -79,7 +78,8 @@ func newClientCfg(endpoints []string, dialTimeout time.Duration, scfg *secureCfg
 	return cfg, nil
 }
 
-func (c *Client) New() {
+// Connect connects to etcd using endpoints, TLS and authentication of the configuration
+func (c *Client) Connect() error {
 	capnslog.SetGlobalLogLevel(logLevel)
 	clientv3.SetLogger(plog)
 
@@ -98,12 +98,12 @@ func (c *Client) New() {
 
 	cfg, err := newClientCfg(endpoints, dialTimeout, sec, auth)
 	if err != nil {
-		log.Panic(err)
+		return err
 	}
 
 	cli, err := clientv3.New(*cfg)
 	if err != nil {
-		log.Panic(err)
+		return err
 	}
 
 	cli.KV = namespace.NewKV(cli.KV, viper.GetString("etcd.prefix"))
@@ -111,28 +111,66 @@ func (c *Client) New() {
 	cli.Lease = namespace.NewLease(cli.Lease, viper.GetString("etcd.prefix"))
 
 	c.Client = cli
+
+	return nil
 }
 
-func (c *Client) Add(data *pb.Rule, override bool) error {
-	// protobuf
-	out, err := proto.Marshal(data)
+// PutWithTTL stores the object like Put with a lease, thus etcd removes the object after ttl
+func (c *Client) PutWithTTL(kind, name string, data proto.Message, override bool, modRevision int64, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("etcd.command_timeout"))
+	lease, err := c.Client.Grant(ctx, int64(ttl.Seconds()))
+	cancel()
 	if err != nil {
-		log.Fatalln("Failed to encode rule:", err)
+		return fmt.Errorf("Cannot grant lease: %s", err)
 	}
 
-	key := "rule/" + data.Name
-	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("etcd.command_timeout"))
+	return c.put(kind, name, data, putCompare(kind+"/"+name, override, modRevision), clientv3.WithLease(lease.ID))
+}
 
-	// override
-	var txnCompare clientv3.Cmp
+// Put stores the object under kind/name key. In the case when override is false
+// the object is stored only if the key does not exist, otherwise only if it exists.
+// In the case when modRevision is not 0 as well, the object is overridden only if mod revision
+// of the key is still modRevision, i.e. nobody changed the object since it was read.
+// An overridden object keeps its lease, thus an object added with ttl is still removed after it.
+// The version of object is written to history in the same transaction.
+// The result of transaction is available in TxnResponse.
+func (c *Client) Put(kind, name string, data proto.Message, override bool, modRevision int64) error {
 	if override {
-		txnCompare = clientv3util.KeyExists(key)
-	} else {
-		txnCompare = clientv3util.KeyMissing(key)
+		// the key exists, otherwise the transaction condition fails
+		return c.put(kind, name, data, putCompare(kind+"/"+name, override, modRevision), clientv3.WithIgnoreLease())
 	}
+
+	return c.put(kind, name, data, putCompare(kind+"/"+name, override, modRevision))
+}
+
+// putCompare returns condition of transaction which stores the object
+func putCompare(key string, override bool, modRevision int64) clientv3.Cmp {
+	switch {
+	case !override:
+		return clientv3util.KeyMissing(key)
+	case modRevision != 0:
+		return clientv3.Compare(clientv3.ModRevision(key), "=", modRevision)
+	default:
+		return clientv3util.KeyExists(key)
+	}
+}
+
+func (c *Client) put(kind, name string, data proto.Message, txnCompare clientv3.Cmp, opts ...clientv3.OpOption) error {
+	// protobuf
+	out, err := proto.Marshal(data)
+	if err != nil {
+		log.Fatalln("Failed to encode object:", err)
+	}
+
+	key := kind + "/" + name
+	history, err := c.historyOps(kind, name, out, false, viper.GetInt("etcd.max_txn_ops")-2)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("etcd.command_timeout"))
 	c.TxnResponse, err = c.Client.Txn(ctx).
 		If(txnCompare).
-		Then(clientv3.OpPut(key, string(out))).
+		Then(append([]clientv3.Op{clientv3.OpPut(key, string(out), opts...)}, history...)...).
 		Commit()
 
 	cancel()
@@ -155,65 +193,69 @@ func (c *Client) Add(data *pb.Rule, override bool) error {
 	return err
 }
 
-func (c *Client) GetAll(limit int64) (*pb.RulesList, error) {
-	var rule *pb.Rule
+// Fetch decodes the object stored under kind/name key into data
+func (c *Client) Fetch(kind, name string, data proto.Message) error {
 	var err error
 
-	result := &pb.RulesList{}
-
 	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("etcd.command_timeout"))
-	c.GetResponse, err = c.Client.Get(ctx, "rule", clientv3.WithLimit(limit), clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	c.GetResponse, err = c.Client.Get(ctx, kind+"/"+name)
 	cancel()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	for _, ev := range c.GetResponse.Kvs {
-		rule = &pb.Rule{}
-		if err := proto.Unmarshal(ev.Value, rule); err != nil {
-			return nil, fmt.Errorf("Failed to parse rule: %s", err)
-		}
+	if c.GetResponse.Count == 0 {
+		return fmt.Errorf("Cannot find %s", kind)
+	}
 
-		result.Rule = append(result.Rule, rule)
+	if err := proto.Unmarshal(c.GetResponse.Kvs[0].Value, data); err != nil {
+		return fmt.Errorf("Failed to parse %s: %s", kind, err)
 	}
-	return result, nil
+
+	return nil
 }
 
-func (c *Client) Get(key string) (*pb.Rule, error) {
+// List returns encoded objects of the kind sorted by name
+func (c *Client) List(kind string, limit int64) ([][]byte, error) {
 	var err error
-	rule := &pb.Rule{}
+	var result [][]byte
 
 	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("etcd.command_timeout"))
-	c.GetResponse, err = c.Client.Get(ctx, key)
+	c.GetResponse, err = c.Client.Get(ctx, kind+"/", clientv3.WithLimit(limit), clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
 	cancel()
 	if err != nil {
-		log.Fatal(err)
-	}
-
-	if c.GetResponse.Count == 0 {
-		return rule, fmt.Errorf("Cannot find rule")
+		return nil, err
 	}
 
 	for _, ev := range c.GetResponse.Kvs {
-		rule = &pb.Rule{}
-		if err := proto.Unmarshal(ev.Value, rule); err != nil {
-			log.Fatalln("Failed to parse rule:", err)
-		}
-
+		result = append(result, ev.Value)
 	}
-	return rule, nil
+	return result, nil
 }
 
-func (c *Client) Delete(key string) error {
-	var err error
+// Remove deletes the object stored under kind/name key, the deletion is written
+// to history and the history is trimmed in the same transaction
+func (c *Client) Remove(kind, name string) error {
+	key := kind + "/" + name
+	history, err := c.historyOps(kind, name, nil, true, viper.GetInt("etcd.max_txn_ops")-2)
+	if err != nil {
+		return err
+	}
 
-	key = "rule/" + key
 	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("etcd.command_timeout"))
-	c.DeleteResponse, err = c.Client.Delete(ctx, key)
+	c.TxnResponse, err = c.Client.Txn(ctx).
+		If(clientv3util.KeyExists(key)).
+		Then(append([]clientv3.Op{clientv3.OpDelete(key)}, history...)...).
+		Commit()
 	cancel()
 	if err != nil {
 		return err
 	}
 
+	c.DeleteResponse = &clientv3.DeleteResponse{Header: c.TxnResponse.Header}
+	if c.TxnResponse.Succeeded {
+		c.DeleteResponse = (*clientv3.DeleteResponse)(c.TxnResponse.Responses[0].GetResponseDeleteRange())
+	}
+
 	return nil
 }
@@ -0,0 +1,127 @@
+package etcd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/pb"
+	"golang.org/x/net/context"
+)
+
+// historyPrefix is the prefix of keys which keep versions of objects,
+// every version is kept under history/kind/name/timestamp key
+const historyPrefix = "history/"
+
+// historyKey returns prefix of keys of versions of the object
+func historyKey(kind, name string) string {
+	return historyPrefix + kind + "/" + name + "/"
+}
+
+// historyOp returns operation which writes the version of object to history.
+// The revision of version is mod revision of its key, which is the same as mod revision
+// of the object because both of them are written in the same transaction.
+func historyOp(kind, name string, data []byte, deleted bool) (clientv3.Op, error) {
+	now := time.Now()
+
+	out, err := proto.Marshal(&pb.Version{
+		Timestamp: now.UnixNano(),
+		Deleted:   deleted,
+		Data:      data,
+	})
+	if err != nil {
+		return clientv3.Op{}, fmt.Errorf("Failed to encode version of %s: %s", kind, err)
+	}
+
+	return clientv3.OpPut(fmt.Sprintf("%s%019d", historyKey(kind, name), now.UnixNano()), string(out)), nil
+}
+
+// historyOps returns operations which write the version of object to history and delete
+// the oldest versions in the case when the object would have more than etcd.history_limit
+// versions, 0 keeps all of them. At most trim versions are deleted, thus the transaction
+// does not exceed etcd.max_txn_ops.
+func (c *Client) historyOps(kind, name string, data []byte, deleted bool, trim int) ([]clientv3.Op, error) {
+	history, err := historyOp(kind, name, data, deleted)
+	if err != nil {
+		return nil, err
+	}
+	ops := []clientv3.Op{history}
+
+	limit := viper.GetInt("etcd.history_limit")
+	if limit <= 0 {
+		return ops, nil
+	}
+
+	prefix := historyKey(kind, name)
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("etcd.command_timeout"))
+	resp, err := c.Client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, kv := range resp.Kvs {
+		if isVersionKey(prefix, string(kv.Key)) {
+			keys = append(keys, string(kv.Key))
+		}
+	}
+
+	// the new version is one of limit versions
+	for i := 0; i < len(keys)+1-limit && i < trim; i++ {
+		ops = append(ops, clientv3.OpDelete(keys[i]))
+	}
+
+	return ops, nil
+}
+
+// isVersionKey checks if the key is a version of the object with the history prefix,
+// versions of objects whose names start with name/ have the same prefix
+func isVersionKey(prefix, key string) bool {
+	return !strings.Contains(strings.TrimPrefix(key, prefix), "/")
+}
+
+// History returns versions of the object from the oldest one
+func (c *Client) History(kind, name string) ([]*mvccpb.KeyValue, error) {
+	var err error
+	var result []*mvccpb.KeyValue
+
+	prefix := historyKey(kind, name)
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("etcd.command_timeout"))
+	c.GetResponse, err = c.Client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, kv := range c.GetResponse.Kvs {
+		if isVersionKey(prefix, string(kv.Key)) {
+			result = append(result, kv)
+		}
+	}
+
+	return result, nil
+}
+
+// ModRevision returns mod revision of the key of object, it is 0 in the case
+// when the object does not exist
+func (c *Client) ModRevision(kind, name string) (int64, error) {
+	var err error
+
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("etcd.command_timeout"))
+	c.GetResponse, err = c.Client.Get(ctx, kind+"/"+name, clientv3.WithKeysOnly())
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	if c.GetResponse.Count == 0 {
+		return 0, nil
+	}
+
+	return c.GetResponse.Kvs[0].ModRevision, nil
+}
@@ -0,0 +1,47 @@
+package etcd
+
+import (
+	"github.com/coreos/etcd/clientv3"
+	"github.com/spf13/viper"
+	"golang.org/x/net/context"
+)
+
+// ListKinds returns all objects of the kinds read in one transaction, thus all of them
+// are read at the same revision. The response contains one range response per kind.
+// History of objects is not read.
+func (c *Client) ListKinds(kinds []string) (*clientv3.TxnResponse, error) {
+	var err error
+	var ops []clientv3.Op
+
+	for _, kind := range kinds {
+		ops = append(ops, clientv3.OpGet(kind+"/", clientv3.WithPrefix()))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("etcd.command_timeout"))
+	c.TxnResponse, err = c.Client.Txn(ctx).Then(ops...).Commit()
+	cancel()
+
+	return c.TxnResponse, err
+}
+
+// Revision returns current revision of etcd
+func (c *Client) Revision() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("etcd.command_timeout"))
+	resp, err := c.Client.Get(ctx, "", clientv3.WithPrefix(), clientv3.WithCountOnly())
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Header.Revision, nil
+}
+
+// Watch watches changes of all objects under the prefix starting from the revision.
+// The watch is canceled in the case when etcd loses the leader, and etcd sends
+// progress notifications when there are no changes.
+func (c *Client) Watch(ctx context.Context, revision int64) clientv3.WatchChan {
+	return c.Client.Watch(clientv3.WithRequireLeader(ctx), "",
+		clientv3.WithPrefix(),
+		clientv3.WithRev(revision),
+		clientv3.WithProgressNotify())
+}
@@ -0,0 +1,13 @@
+package kir
+
+deny[msg] {
+	container := input.spec.containers[_]
+	endswith(container.image, ":latest")
+	msg := sprintf("%s: latest tag is not allowed", [container.image])
+}
+
+deny[msg] {
+	input.spec.namespace == "production"
+	not input.spec.annotations["team.image-policy.k8s.io/name"]
+	msg := "production: team annotation is required"
+}
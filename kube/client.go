@@ -0,0 +1,26 @@
+package kube
+
+import (
+	"github.com/spf13/viper"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// NewClient creates Kubernetes client using kubeconfig from configuration,
+// or in-cluster configuration in the case when kubeconfig is not given
+func NewClient() (kubernetes.Interface, error) {
+	var config *rest.Config
+	var err error
+
+	if kubeconfig := viper.GetString("kubernetes.kubeconfig"); kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(config)
+}
@@ -0,0 +1,91 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NamespaceCache keeps labels of namespaces up to date using a shared informer,
+// thus labels are looked up without a request to the Kubernetes API.
+type NamespaceCache struct {
+	factory informers.SharedInformerFactory
+	lister  corelisters.NamespaceLister
+	synced  cache.InformerSynced
+}
+
+// NewNamespaceCache creates cache of namespaces, the client can be a fake clientset
+func NewNamespaceCache(client kubernetes.Interface, resync time.Duration) *NamespaceCache {
+	factory := informers.NewSharedInformerFactory(client, resync)
+	namespaces := factory.Core().V1().Namespaces()
+
+	return &NamespaceCache{
+		factory: factory,
+		lister:  namespaces.Lister(),
+		synced:  namespaces.Informer().HasSynced,
+	}
+}
+
+// Run starts the informer and waits until the cache is synced, but not longer than timeout.
+// In the case when the cache is not synced in time the informer keeps running,
+// and labels are available as soon as it is synced.
+func (c *NamespaceCache) Run(stop <-chan struct{}, timeout time.Duration) error {
+	c.factory.Start(stop)
+
+	// wait is closed when the timeout is reached, the informer is stopped or Run returns
+	wait := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		defer close(wait)
+
+		select {
+		case <-stop:
+		case <-done:
+		case <-time.After(timeout):
+		}
+	}()
+
+	if !cache.WaitForCacheSync(wait, c.synced) {
+		return fmt.Errorf("Cannot sync cache of namespaces in %s", timeout)
+	}
+
+	return nil
+}
+
+// Labels returns labels of the namespace. The error is returned until the cache is synced.
+func (c *NamespaceCache) Labels(name string) (map[string]string, error) {
+	if !c.synced() {
+		return nil, fmt.Errorf("cache of namespaces is not synced yet")
+	}
+
+	namespace, err := c.lister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return namespace.Labels, nil
+}
+
+// NamespaceClient looks up labels of namespaces directly in the Kubernetes API.
+// It is meant for short-lived commands, the server uses NamespaceCache.
+type NamespaceClient struct {
+	Client kubernetes.Interface
+}
+
+// Labels returns labels of the namespace
+func (c *NamespaceClient) Labels(name string) (map[string]string, error) {
+	namespace, err := c.Client.CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return namespace.Labels, nil
+}
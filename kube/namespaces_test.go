@@ -0,0 +1,138 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/policy"
+	"github.com/tczekajlo/kir/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func namespace(name string, nsLabels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nsLabels}}
+}
+
+func runCache(t *testing.T, client *fake.Clientset) *NamespaceCache {
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+
+	c := NewNamespaceCache(client, time.Minute)
+	if err := c.Run(stop, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestNamespaceSelectorReview(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		namespace("prod-payments", map[string]string{"env": "prod", "team": "payments"}),
+		namespace("prod-web", map[string]string{"env": "prod", "team": "web"}),
+		namespace("dev", map[string]string{"env": "dev"}),
+	)
+	policy.SetNamespaceLabels(runCache(t, client))
+	defer policy.SetNamespaceLabels(nil)
+
+	viper.Set("server.default_allowed", true)
+	defer viper.Set("server.default_allowed", nil)
+
+	// images which are not pinned by digest are denied in production namespaces of payments
+	s := policy.NewRuleSet(&policy.Source{Rules: []*pb.Rule{{
+		Name:              "pinned",
+		Allowed:           true,
+		RequireDigest:     true,
+		NamespaceSelector: "env=prod,team in (payments,risk)",
+		Containers:        []*pb.Rule_Containers{{Image: "."}},
+	}}})
+
+	tests := []struct {
+		namespace string
+		allowed   bool
+	}{
+		{"prod-payments", false},
+		{"prod-web", true},
+		{"dev", true},
+
+		// labels of a namespace which does not exist cannot match
+		{"missing", true},
+	}
+
+	for _, test := range tests {
+		req := &types.ImageReview{Spec: types.ImageReviewSpec{
+			Namespace:  test.namespace,
+			Containers: []types.ImageReviewContainerSpec{{Image: "nginx:1.19"}},
+		}}
+
+		if resp := s.Review(req); resp.Status.Allowed != test.allowed {
+			t.Errorf("%s: got allowed %t, want %t: %s", test.namespace, resp.Status.Allowed, test.allowed, resp.Status.Reason)
+		}
+	}
+}
+
+func TestNamespaceCacheUpdates(t *testing.T) {
+	client := fake.NewSimpleClientset(namespace("team-a", map[string]string{"env": "dev"}))
+	c := runCache(t, client)
+
+	namespaces := client.CoreV1().Namespaces()
+	if _, err := namespaces.Update(context.Background(), namespace("team-a", map[string]string{"env": "prod"}), metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := namespaces.Create(context.Background(), namespace("team-b", map[string]string{"env": "prod"}), metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		namespace string
+		env       string
+	}{
+		{"team-a", "prod"},
+		{"team-b", "prod"},
+	}
+
+	for _, test := range tests {
+		var env string
+		for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if nsLabels, err := c.Labels(test.namespace); err == nil {
+				if env = nsLabels["env"]; env == test.env {
+					break
+				}
+			}
+		}
+
+		if env != test.env {
+			t.Errorf("%s: got env %q, want %q", test.namespace, env, test.env)
+		}
+	}
+}
+
+func TestNamespaceCacheSyncTimeout(t *testing.T) {
+	client := fake.NewSimpleClientset(namespace("prod", map[string]string{"env": "prod"}))
+
+	// the API does not answer until the test is finished
+	unblock := make(chan struct{})
+	defer close(unblock)
+	client.PrependReactor("list", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		<-unblock
+		return false, nil, nil
+	})
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	c := NewNamespaceCache(client, time.Minute)
+	if err := c.Run(stop, 100*time.Millisecond); err == nil {
+		t.Fatal("Run: expected timeout error")
+	}
+
+	if _, err := c.Labels("prod"); err == nil {
+		t.Error("Labels before sync: expected error")
+	}
+}
@@ -11,6 +11,15 @@ It is generated from these files:
 It has these top-level messages:
 	Rule
 	RulesList
+	Default
+	DefaultsList
+	Policy
+	PoliciesList
+	PolicySet
+	PolicySetsList
+	Binding
+	BindingsList
+	Version
 */
 package pb
 
@@ -30,12 +39,30 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type Rule struct {
-	Name        string             `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	Allowed     bool               `protobuf:"varint,2,opt,name=allowed" json:"allowed,omitempty"`
-	Containers  []*Rule_Containers `protobuf:"bytes,3,rep,name=containers" json:"containers,omitempty"`
-	Namespace   string             `protobuf:"bytes,4,opt,name=namespace" json:"namespace,omitempty"`
-	Annotations map[string]string  `protobuf:"bytes,5,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Reason      string             `protobuf:"bytes,6,opt,name=reason" json:"reason,omitempty"`
+	Name                 string             `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Allowed              bool               `protobuf:"varint,2,opt,name=allowed" json:"allowed,omitempty"`
+	Containers           []*Rule_Containers `protobuf:"bytes,3,rep,name=containers" json:"containers,omitempty"`
+	Namespace            string             `protobuf:"bytes,4,opt,name=namespace" json:"namespace,omitempty"`
+	Annotations          map[string]string  `protobuf:"bytes,5,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Reason               string             `protobuf:"bytes,6,opt,name=reason" json:"reason,omitempty"`
+	Priority             int32              `protobuf:"varint,7,opt,name=priority" json:"priority,omitempty"`
+	RequireDigest        bool               `protobuf:"varint,8,opt,name=require_digest,json=requireDigest" json:"require_digest,omitempty"`
+	DenyMutableTags      bool               `protobuf:"varint,9,opt,name=deny_mutable_tags,json=denyMutableTags" json:"deny_mutable_tags,omitempty"`
+	MutableTags          []string           `protobuf:"bytes,10,rep,name=mutable_tags,json=mutableTags" json:"mutable_tags,omitempty"`
+	NamespaceMatchType   string             `protobuf:"bytes,11,opt,name=namespace_match_type,json=namespaceMatchType" json:"namespace_match_type,omitempty"`
+	AnnotationsMatchType string             `protobuf:"bytes,12,opt,name=annotations_match_type,json=annotationsMatchType" json:"annotations_match_type,omitempty"`
+	Mode                 string             `protobuf:"bytes,13,opt,name=mode" json:"mode,omitempty"`
+	ExcludeImages        []*Rule_Containers `protobuf:"bytes,14,rep,name=exclude_images,json=excludeImages" json:"exclude_images,omitempty"`
+	ExcludeNamespaces    []string           `protobuf:"bytes,15,rep,name=exclude_namespaces,json=excludeNamespaces" json:"exclude_namespaces,omitempty"`
+	AnnotationsMatch     string             `protobuf:"bytes,16,opt,name=annotations_match,json=annotationsMatch" json:"annotations_match,omitempty"`
+	ForbiddenAnnotations map[string]string  `protobuf:"bytes,17,rep,name=forbidden_annotations,json=forbiddenAnnotations" json:"forbidden_annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ValidFrom            string             `protobuf:"bytes,18,opt,name=valid_from,json=validFrom" json:"valid_from,omitempty"`
+	ValidUntil           string             `protobuf:"bytes,19,opt,name=valid_until,json=validUntil" json:"valid_until,omitempty"`
+	Windows              []*Rule_Window     `protobuf:"bytes,20,rep,name=windows" json:"windows,omitempty"`
+	Condition            string             `protobuf:"bytes,21,opt,name=condition" json:"condition,omitempty"`
+	Metadata             map[string]string  `protobuf:"bytes,22,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	NamespaceSelector    string             `protobuf:"bytes,23,opt,name=namespace_selector,json=namespaceSelector" json:"namespace_selector,omitempty"`
+	ResourceVersion      int64              `protobuf:"varint,24,opt,name=resource_version,json=resourceVersion" json:"resource_version,omitempty"`
 }
 
 func (m *Rule) Reset()                    { *m = Rule{} }
@@ -85,8 +112,141 @@ func (m *Rule) GetReason() string {
 	return ""
 }
 
+func (m *Rule) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Rule) GetRequireDigest() bool {
+	if m != nil {
+		return m.RequireDigest
+	}
+	return false
+}
+
+func (m *Rule) GetDenyMutableTags() bool {
+	if m != nil {
+		return m.DenyMutableTags
+	}
+	return false
+}
+
+func (m *Rule) GetMutableTags() []string {
+	if m != nil {
+		return m.MutableTags
+	}
+	return nil
+}
+
+func (m *Rule) GetNamespaceMatchType() string {
+	if m != nil {
+		return m.NamespaceMatchType
+	}
+	return ""
+}
+
+func (m *Rule) GetAnnotationsMatchType() string {
+	if m != nil {
+		return m.AnnotationsMatchType
+	}
+	return ""
+}
+
+func (m *Rule) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *Rule) GetExcludeImages() []*Rule_Containers {
+	if m != nil {
+		return m.ExcludeImages
+	}
+	return nil
+}
+
+func (m *Rule) GetExcludeNamespaces() []string {
+	if m != nil {
+		return m.ExcludeNamespaces
+	}
+	return nil
+}
+
+func (m *Rule) GetAnnotationsMatch() string {
+	if m != nil {
+		return m.AnnotationsMatch
+	}
+	return ""
+}
+
+func (m *Rule) GetForbiddenAnnotations() map[string]string {
+	if m != nil {
+		return m.ForbiddenAnnotations
+	}
+	return nil
+}
+
+func (m *Rule) GetValidFrom() string {
+	if m != nil {
+		return m.ValidFrom
+	}
+	return ""
+}
+
+func (m *Rule) GetValidUntil() string {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return ""
+}
+
+func (m *Rule) GetWindows() []*Rule_Window {
+	if m != nil {
+		return m.Windows
+	}
+	return nil
+}
+
+func (m *Rule) GetCondition() string {
+	if m != nil {
+		return m.Condition
+	}
+	return ""
+}
+
+func (m *Rule) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Rule) GetNamespaceSelector() string {
+	if m != nil {
+		return m.NamespaceSelector
+	}
+	return ""
+}
+
+func (m *Rule) GetResourceVersion() int64 {
+	if m != nil {
+		return m.ResourceVersion
+	}
+	return 0
+}
+
 type Rule_Containers struct {
-	Image string `protobuf:"bytes,1,opt,name=image" json:"image,omitempty"`
+	Image         string `protobuf:"bytes,1,opt,name=image" json:"image,omitempty"`
+	Registry      string `protobuf:"bytes,2,opt,name=registry" json:"registry,omitempty"`
+	Repository    string `protobuf:"bytes,3,opt,name=repository" json:"repository,omitempty"`
+	Tag           string `protobuf:"bytes,4,opt,name=tag" json:"tag,omitempty"`
+	Digest        string `protobuf:"bytes,5,opt,name=digest" json:"digest,omitempty"`
+	Semver        string `protobuf:"bytes,6,opt,name=semver" json:"semver,omitempty"`
+	InvalidSemver string `protobuf:"bytes,7,opt,name=invalid_semver,json=invalidSemver" json:"invalid_semver,omitempty"`
+	MatchType     string `protobuf:"bytes,8,opt,name=match_type,json=matchType" json:"match_type,omitempty"`
 }
 
 func (m *Rule_Containers) Reset()                    { *m = Rule_Containers{} }
@@ -101,6 +261,95 @@ func (m *Rule_Containers) GetImage() string {
 	return ""
 }
 
+func (m *Rule_Containers) GetRegistry() string {
+	if m != nil {
+		return m.Registry
+	}
+	return ""
+}
+
+func (m *Rule_Containers) GetRepository() string {
+	if m != nil {
+		return m.Repository
+	}
+	return ""
+}
+
+func (m *Rule_Containers) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *Rule_Containers) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *Rule_Containers) GetSemver() string {
+	if m != nil {
+		return m.Semver
+	}
+	return ""
+}
+
+func (m *Rule_Containers) GetInvalidSemver() string {
+	if m != nil {
+		return m.InvalidSemver
+	}
+	return ""
+}
+
+func (m *Rule_Containers) GetMatchType() string {
+	if m != nil {
+		return m.MatchType
+	}
+	return ""
+}
+
+type Rule_Window struct {
+	Days     []string `protobuf:"bytes,1,rep,name=days" json:"days,omitempty"`
+	Start    string   `protobuf:"bytes,2,opt,name=start" json:"start,omitempty"`
+	End      string   `protobuf:"bytes,3,opt,name=end" json:"end,omitempty"`
+	Timezone string   `protobuf:"bytes,4,opt,name=timezone" json:"timezone,omitempty"`
+}
+
+func (m *Rule_Window) Reset()                    { *m = Rule_Window{} }
+func (m *Rule_Window) String() string            { return proto.CompactTextString(m) }
+func (*Rule_Window) ProtoMessage()               {}
+func (*Rule_Window) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0, 1} }
+
+func (m *Rule_Window) GetDays() []string {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *Rule_Window) GetStart() string {
+	if m != nil {
+		return m.Start
+	}
+	return ""
+}
+
+func (m *Rule_Window) GetEnd() string {
+	if m != nil {
+		return m.End
+	}
+	return ""
+}
+
+func (m *Rule_Window) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
 type RulesList struct {
 	Rule []*Rule `protobuf:"bytes,1,rep,name=rule" json:"rule,omitempty"`
 }
@@ -117,31 +366,318 @@ func (m *RulesList) GetRule() []*Rule {
 	return nil
 }
 
+type Default struct {
+	Name               string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Namespace          string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	NamespaceMatchType string `protobuf:"bytes,3,opt,name=namespace_match_type,json=namespaceMatchType" json:"namespace_match_type,omitempty"`
+	Allowed            bool   `protobuf:"varint,4,opt,name=allowed" json:"allowed,omitempty"`
+	Reason             string `protobuf:"bytes,5,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *Default) Reset()                    { *m = Default{} }
+func (m *Default) String() string            { return proto.CompactTextString(m) }
+func (*Default) ProtoMessage()               {}
+func (*Default) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *Default) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Default) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Default) GetNamespaceMatchType() string {
+	if m != nil {
+		return m.NamespaceMatchType
+	}
+	return ""
+}
+
+func (m *Default) GetAllowed() bool {
+	if m != nil {
+		return m.Allowed
+	}
+	return false
+}
+
+func (m *Default) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type DefaultsList struct {
+	Default []*Default `protobuf:"bytes,1,rep,name=default" json:"default,omitempty"`
+}
+
+func (m *DefaultsList) Reset()                    { *m = DefaultsList{} }
+func (m *DefaultsList) String() string            { return proto.CompactTextString(m) }
+func (*DefaultsList) ProtoMessage()               {}
+func (*DefaultsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *DefaultsList) GetDefault() []*Default {
+	if m != nil {
+		return m.Default
+	}
+	return nil
+}
+
+type Policy struct {
+	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Module string `protobuf:"bytes,2,opt,name=module" json:"module,omitempty"`
+}
+
+func (m *Policy) Reset()                    { *m = Policy{} }
+func (m *Policy) String() string            { return proto.CompactTextString(m) }
+func (*Policy) ProtoMessage()               {}
+func (*Policy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Policy) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Policy) GetModule() string {
+	if m != nil {
+		return m.Module
+	}
+	return ""
+}
+
+type PoliciesList struct {
+	Policy []*Policy `protobuf:"bytes,1,rep,name=policy" json:"policy,omitempty"`
+}
+
+func (m *PoliciesList) Reset()                    { *m = PoliciesList{} }
+func (m *PoliciesList) String() string            { return proto.CompactTextString(m) }
+func (*PoliciesList) ProtoMessage()               {}
+func (*PoliciesList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *PoliciesList) GetPolicy() []*Policy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+type PolicySet struct {
+	Name  string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Rules []string `protobuf:"bytes,2,rep,name=rules" json:"rules,omitempty"`
+}
+
+func (m *PolicySet) Reset()                    { *m = PolicySet{} }
+func (m *PolicySet) String() string            { return proto.CompactTextString(m) }
+func (*PolicySet) ProtoMessage()               {}
+func (*PolicySet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *PolicySet) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PolicySet) GetRules() []string {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type PolicySetsList struct {
+	Set []*PolicySet `protobuf:"bytes,1,rep,name=set" json:"set,omitempty"`
+}
+
+func (m *PolicySetsList) Reset()                    { *m = PolicySetsList{} }
+func (m *PolicySetsList) String() string            { return proto.CompactTextString(m) }
+func (*PolicySetsList) ProtoMessage()               {}
+func (*PolicySetsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *PolicySetsList) GetSet() []*PolicySet {
+	if m != nil {
+		return m.Set
+	}
+	return nil
+}
+
+type Binding struct {
+	Name               string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Namespaces         []string `protobuf:"bytes,2,rep,name=namespaces" json:"namespaces,omitempty"`
+	NamespaceMatchType string   `protobuf:"bytes,3,opt,name=namespace_match_type,json=namespaceMatchType" json:"namespace_match_type,omitempty"`
+	Sets               []string `protobuf:"bytes,4,rep,name=sets" json:"sets,omitempty"`
+}
+
+func (m *Binding) Reset()                    { *m = Binding{} }
+func (m *Binding) String() string            { return proto.CompactTextString(m) }
+func (*Binding) ProtoMessage()               {}
+func (*Binding) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *Binding) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Binding) GetNamespaces() []string {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+func (m *Binding) GetNamespaceMatchType() string {
+	if m != nil {
+		return m.NamespaceMatchType
+	}
+	return ""
+}
+
+func (m *Binding) GetSets() []string {
+	if m != nil {
+		return m.Sets
+	}
+	return nil
+}
+
+type BindingsList struct {
+	Binding []*Binding `protobuf:"bytes,1,rep,name=binding" json:"binding,omitempty"`
+}
+
+func (m *BindingsList) Reset()                    { *m = BindingsList{} }
+func (m *BindingsList) String() string            { return proto.CompactTextString(m) }
+func (*BindingsList) ProtoMessage()               {}
+func (*BindingsList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *BindingsList) GetBinding() []*Binding {
+	if m != nil {
+		return m.Binding
+	}
+	return nil
+}
+
+type Version struct {
+	Timestamp int64  `protobuf:"varint,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	Deleted   bool   `protobuf:"varint,2,opt,name=deleted" json:"deleted,omitempty"`
+	Data      []byte `protobuf:"bytes,3,opt,name=data" json:"data,omitempty"`
+}
+
+func (m *Version) Reset()                    { *m = Version{} }
+func (m *Version) String() string            { return proto.CompactTextString(m) }
+func (*Version) ProtoMessage()               {}
+func (*Version) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *Version) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Version) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+func (m *Version) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Rule)(nil), "pb.Rule")
 	proto.RegisterType((*Rule_Containers)(nil), "pb.Rule.Containers")
+	proto.RegisterType((*Rule_Window)(nil), "pb.Rule.Window")
 	proto.RegisterType((*RulesList)(nil), "pb.RulesList")
+	proto.RegisterType((*Default)(nil), "pb.Default")
+	proto.RegisterType((*DefaultsList)(nil), "pb.DefaultsList")
+	proto.RegisterType((*Policy)(nil), "pb.Policy")
+	proto.RegisterType((*PoliciesList)(nil), "pb.PoliciesList")
+	proto.RegisterType((*PolicySet)(nil), "pb.PolicySet")
+	proto.RegisterType((*PolicySetsList)(nil), "pb.PolicySetsList")
+	proto.RegisterType((*Binding)(nil), "pb.Binding")
+	proto.RegisterType((*BindingsList)(nil), "pb.BindingsList")
+	proto.RegisterType((*Version)(nil), "pb.Version")
 }
 
 func init() { proto.RegisterFile("rules.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 263 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x90, 0xc1, 0x4e, 0xf3, 0x30,
-	0x10, 0x84, 0xe5, 0x24, 0xcd, 0xdf, 0x6c, 0x2e, 0xd5, 0xfe, 0x08, 0x99, 0xaa, 0x87, 0x28, 0xa7,
-	0x70, 0xc9, 0x81, 0x5e, 0x10, 0x48, 0x48, 0x08, 0x71, 0xe3, 0xe4, 0x37, 0x70, 0xca, 0x0a, 0x45,
-	0xb8, 0x76, 0x64, 0x3b, 0xa0, 0xbe, 0x1d, 0x8f, 0x86, 0xec, 0x36, 0x4d, 0xc5, 0x6d, 0x67, 0x77,
-	0xf2, 0x65, 0x3c, 0x50, 0xda, 0x51, 0x91, 0x6b, 0x07, 0x6b, 0xbc, 0xc1, 0x64, 0xe8, 0xea, 0x9f,
-	0x04, 0x32, 0x31, 0x2a, 0x42, 0x84, 0x4c, 0xcb, 0x3d, 0x71, 0x56, 0xb1, 0xa6, 0x10, 0x71, 0x46,
-	0x0e, 0xff, 0xa4, 0x52, 0xe6, 0x9b, 0xde, 0x79, 0x52, 0xb1, 0x66, 0x29, 0x26, 0x89, 0x5b, 0x80,
-	0x9d, 0xd1, 0x5e, 0xf6, 0x9a, 0xac, 0xe3, 0x69, 0x95, 0x36, 0xe5, 0xdd, 0xff, 0x76, 0xe8, 0xda,
-	0xc0, 0x6a, 0x5f, 0xce, 0x27, 0x71, 0x61, 0xc3, 0x0d, 0x14, 0x01, 0xeb, 0x06, 0xb9, 0x23, 0x9e,
-	0xc5, 0xff, 0xcc, 0x0b, 0x7c, 0x84, 0x52, 0x6a, 0x6d, 0xbc, 0xf4, 0xbd, 0xd1, 0x8e, 0x2f, 0x22,
-	0xf3, 0xe6, 0xcc, 0x7c, 0x9e, 0x6f, 0xaf, 0xda, 0xdb, 0x83, 0xb8, 0x74, 0xe3, 0x35, 0xe4, 0x96,
-	0xa4, 0x33, 0x9a, 0xe7, 0x91, 0x7b, 0x52, 0xeb, 0x1a, 0x60, 0x0e, 0x83, 0x57, 0xb0, 0xe8, 0xf7,
-	0xf2, 0x63, 0x7a, 0xe4, 0x51, 0xac, 0x9f, 0x60, 0xf5, 0x17, 0x8e, 0x2b, 0x48, 0x3f, 0xe9, 0x70,
-	0xf2, 0x85, 0x31, 0x7c, 0xfb, 0x25, 0xd5, 0x48, 0xb1, 0x89, 0x42, 0x1c, 0xc5, 0x43, 0x72, 0xcf,
-	0xea, 0x5b, 0x28, 0x42, 0x42, 0xf7, 0xd6, 0x3b, 0x8f, 0x1b, 0xc8, 0x42, 0xc5, 0x9c, 0xc5, 0xf8,
-	0xcb, 0x29, 0xbe, 0x88, 0xdb, 0x2e, 0x8f, 0xc5, 0x6f, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xc4,
-	0x07, 0xb3, 0x17, 0x87, 0x01, 0x00, 0x00,
+	// 978 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x5b, 0x6f, 0xdc, 0x44,
+	0x14, 0x96, 0xb3, 0xf7, 0xb3, 0xbb, 0xb9, 0x4c, 0xb7, 0x61, 0x1a, 0xf5, 0xb2, 0x58, 0xaa, 0xb4,
+	0x01, 0x11, 0x41, 0xda, 0x4a, 0xa8, 0x95, 0x90, 0x80, 0x52, 0x84, 0x44, 0x10, 0x72, 0x5a, 0xfa,
+	0xb8, 0xcc, 0xae, 0x4f, 0x96, 0x11, 0xb6, 0xc7, 0xcc, 0x8c, 0x13, 0xcc, 0x6b, 0x7f, 0x08, 0xff,
+	0x8e, 0xdf, 0x81, 0xe6, 0xe2, 0x4b, 0xd2, 0xcd, 0x43, 0xfb, 0x36, 0xe7, 0xfb, 0xce, 0x9c, 0xcb,
+	0x9c, 0x8b, 0x0d, 0x63, 0x59, 0x24, 0xa8, 0x4e, 0x72, 0x29, 0xb4, 0x20, 0x3b, 0xf9, 0x2a, 0x7c,
+	0x37, 0x81, 0x6e, 0x54, 0x24, 0x48, 0x08, 0x74, 0x33, 0x96, 0x22, 0x0d, 0xe6, 0xc1, 0x62, 0x14,
+	0xd9, 0x33, 0xa1, 0x30, 0x60, 0x49, 0x22, 0xae, 0x30, 0xa6, 0x3b, 0xf3, 0x60, 0x31, 0x8c, 0x2a,
+	0x91, 0x3c, 0x01, 0x58, 0x8b, 0x4c, 0x33, 0x9e, 0xa1, 0x54, 0xb4, 0x33, 0xef, 0x2c, 0xc6, 0xa7,
+	0x77, 0x4e, 0xf2, 0xd5, 0x89, 0xb1, 0x75, 0xf2, 0x7d, 0x4d, 0x45, 0x2d, 0x35, 0x72, 0x1f, 0x46,
+	0xc6, 0xac, 0xca, 0xd9, 0x1a, 0x69, 0xd7, 0xfa, 0x69, 0x00, 0xf2, 0x02, 0xc6, 0x2c, 0xcb, 0x84,
+	0x66, 0x9a, 0x8b, 0x4c, 0xd1, 0x9e, 0xb5, 0x79, 0xaf, 0xb6, 0xf9, 0x6d, 0xc3, 0xfd, 0x90, 0x69,
+	0x59, 0x46, 0x6d, 0x6d, 0x72, 0x08, 0x7d, 0x89, 0x4c, 0x89, 0x8c, 0xf6, 0xad, 0x5d, 0x2f, 0x91,
+	0x23, 0x18, 0xe6, 0x92, 0x0b, 0xc9, 0x75, 0x49, 0x07, 0xf3, 0x60, 0xd1, 0x8b, 0x6a, 0x99, 0x3c,
+	0x86, 0x5d, 0x89, 0x7f, 0x15, 0x5c, 0xe2, 0x32, 0xe6, 0x1b, 0x54, 0x9a, 0x0e, 0x6d, 0x92, 0x53,
+	0x8f, 0xbe, 0xb4, 0x20, 0xf9, 0x0c, 0x0e, 0x62, 0xcc, 0xca, 0x65, 0x5a, 0x68, 0xb6, 0x4a, 0x70,
+	0xa9, 0xd9, 0x46, 0xd1, 0x91, 0xd5, 0xdc, 0x33, 0xc4, 0x99, 0xc3, 0x5f, 0xb3, 0x8d, 0x22, 0x9f,
+	0xc2, 0xe4, 0x9a, 0x1a, 0xcc, 0x3b, 0x8b, 0x51, 0x34, 0x4e, 0x5b, 0x2a, 0x5f, 0xc2, 0xac, 0xce,
+	0x79, 0x99, 0x32, 0xbd, 0xfe, 0x63, 0xa9, 0xcb, 0x1c, 0xe9, 0xd8, 0xc6, 0x4d, 0x6a, 0xee, 0xcc,
+	0x50, 0xaf, 0xcb, 0x1c, 0xc9, 0x53, 0x38, 0x6c, 0xa5, 0xda, 0xbe, 0x33, 0xb1, 0x77, 0x66, 0x2d,
+	0xb6, 0xb9, 0x45, 0xa0, 0x9b, 0x8a, 0x18, 0xe9, 0xd4, 0xd5, 0xd3, 0x9c, 0xc9, 0x73, 0xd8, 0xc5,
+	0xbf, 0xd7, 0x49, 0x11, 0xe3, 0x92, 0xa7, 0x6c, 0x83, 0x8a, 0xee, 0xde, 0x5e, 0xb9, 0xa9, 0x57,
+	0xfd, 0xc9, 0x6a, 0x92, 0x2f, 0x80, 0x54, 0x77, 0xeb, 0x18, 0x15, 0xdd, 0xb3, 0x09, 0x1e, 0x78,
+	0xe6, 0x97, 0x9a, 0x20, 0x9f, 0xc3, 0xc1, 0x7b, 0x41, 0xd3, 0x7d, 0x1b, 0xcb, 0xfe, 0xcd, 0x78,
+	0xc9, 0x5b, 0xb8, 0x7b, 0x21, 0xe4, 0x8a, 0xc7, 0x31, 0x66, 0xcb, 0x16, 0x4b, 0x0f, 0x6c, 0x78,
+	0x61, 0x1d, 0xde, 0xab, 0x4a, 0xeb, 0xbd, 0x6e, 0x98, 0x5d, 0x6c, 0xa1, 0xc8, 0x03, 0x80, 0x4b,
+	0x96, 0xf0, 0x78, 0x79, 0x21, 0x45, 0x4a, 0x89, 0x6b, 0x39, 0x8b, 0xbc, 0x92, 0x22, 0x25, 0x8f,
+	0x60, 0xec, 0xe8, 0x22, 0xd3, 0x3c, 0xa1, 0x77, 0x2c, 0xef, 0x6e, 0xbc, 0x31, 0x08, 0x39, 0x86,
+	0xc1, 0x15, 0xcf, 0x62, 0x71, 0xa5, 0xe8, 0xcc, 0x86, 0xb2, 0x57, 0x87, 0xf2, 0xd6, 0xe2, 0x51,
+	0xc5, 0x9b, 0xe6, 0x5e, 0x8b, 0x2c, 0xe6, 0xc6, 0x31, 0xbd, 0xeb, 0x3c, 0xd5, 0x00, 0x39, 0x85,
+	0x61, 0x8a, 0x9a, 0xc5, 0x4c, 0x33, 0x7a, 0x68, 0x2d, 0x1d, 0xd6, 0x96, 0xce, 0x3c, 0xe1, 0x12,
+	0xa9, 0xf5, 0xcc, 0x8b, 0x37, 0x9d, 0xa2, 0x30, 0xc1, 0xb5, 0x16, 0x92, 0x7e, 0x62, 0x4d, 0x1f,
+	0xd4, 0xcc, 0xb9, 0x27, 0xc8, 0x31, 0xec, 0x4b, 0x54, 0xa2, 0x90, 0x6b, 0x5c, 0x5e, 0xa2, 0x54,
+	0x26, 0x0e, 0x3a, 0x0f, 0x16, 0x9d, 0x68, 0xaf, 0xc2, 0x7f, 0x73, 0xf0, 0xd1, 0x7f, 0x01, 0x40,
+	0x53, 0x69, 0x32, 0x83, 0x9e, 0x6d, 0x07, 0x3f, 0xfb, 0x4e, 0x30, 0xa3, 0x23, 0x71, 0xc3, 0x95,
+	0x96, 0xa5, 0x9d, 0xfe, 0x51, 0x54, 0xcb, 0xe4, 0x21, 0x80, 0xc4, 0x5c, 0x28, 0xae, 0x85, 0x2c,
+	0x69, 0xc7, 0xbd, 0x5b, 0x83, 0x90, 0x7d, 0xe8, 0x68, 0xb6, 0xf1, 0x33, 0x6e, 0x8e, 0x66, 0x40,
+	0xfd, 0x90, 0xf5, 0xdc, 0x80, 0x3a, 0xc9, 0xe0, 0x0a, 0xd3, 0x4b, 0x94, 0xd5, 0xe0, 0x3a, 0xc9,
+	0x0c, 0x27, 0xcf, 0x5c, 0x71, 0x3c, 0x3f, 0xb0, 0xfc, 0xd4, 0xa3, 0xe7, 0x4e, 0xed, 0x01, 0x40,
+	0x6b, 0x1e, 0x86, 0xee, 0xd9, 0xd3, 0x6a, 0x08, 0x8e, 0x7e, 0x87, 0xbe, 0xab, 0x93, 0x19, 0x87,
+	0x98, 0x95, 0x8a, 0x06, 0xb6, 0x61, 0xed, 0xd9, 0xe4, 0xad, 0x34, 0x93, 0xda, 0xa7, 0xe7, 0x04,
+	0x13, 0x3b, 0x66, 0xb1, 0x4f, 0xca, 0x1c, 0xcd, 0x4b, 0x68, 0x9e, 0xe2, 0x3f, 0x22, 0xab, 0xd6,
+	0x56, 0x2d, 0x1f, 0x7d, 0x03, 0xfb, 0x37, 0x7b, 0xd1, 0x58, 0xf8, 0x13, 0x4b, 0xff, 0x9a, 0xe6,
+	0x68, 0x3c, 0x5d, 0xb2, 0xa4, 0xc0, 0xca, 0x93, 0x15, 0x9e, 0xef, 0x7c, 0x1d, 0x1c, 0xfd, 0x08,
+	0xf7, 0x6e, 0x6d, 0xea, 0x0f, 0x32, 0xf4, 0x02, 0xa6, 0xd7, 0x1a, 0xe9, 0x43, 0x2e, 0x87, 0xc7,
+	0x30, 0x32, 0xad, 0xa8, 0x7e, 0xe6, 0x4a, 0x93, 0xfb, 0xd0, 0x35, 0x5f, 0x09, 0xfb, 0x54, 0xe3,
+	0xd3, 0x61, 0xd5, 0xa7, 0x91, 0x45, 0xc3, 0x7f, 0x03, 0x18, 0xbc, 0xc4, 0x0b, 0x56, 0x24, 0x7a,
+	0xeb, 0x37, 0xe3, 0xda, 0x92, 0xdf, 0xb9, 0xb9, 0xe4, 0x6f, 0xdb, 0x7e, 0x9d, 0x5b, 0xb7, 0x5f,
+	0xeb, 0x1b, 0xd4, 0xbd, 0xfe, 0x0d, 0x6a, 0x76, 0x7e, 0xaf, 0xbd, 0xf3, 0xc3, 0x67, 0x30, 0xf1,
+	0x01, 0xba, 0x7c, 0x1e, 0xc3, 0x20, 0x76, 0xb2, 0x4f, 0x69, 0x6c, 0x52, 0xf2, 0x2a, 0x51, 0xc5,
+	0x85, 0x4f, 0xa1, 0xff, 0xab, 0x48, 0xf8, 0xba, 0xdc, 0x9a, 0xd6, 0x21, 0xf4, 0x53, 0x11, 0x17,
+	0x49, 0x95, 0x93, 0x97, 0xc2, 0x53, 0x98, 0xd8, 0x5b, 0xdc, 0x3f, 0x5e, 0x08, 0xfd, 0xdc, 0x5a,
+	0xf1, 0xbe, 0xc0, 0xf8, 0x72, 0x76, 0x23, 0xcf, 0x84, 0xcf, 0x60, 0xe4, 0x90, 0x73, 0xdc, 0xfe,
+	0x86, 0x33, 0xe8, 0xd9, 0xef, 0x34, 0xdd, 0xb1, 0xdd, 0xea, 0x84, 0xf0, 0x2b, 0xd8, 0xad, 0xaf,
+	0x39, 0x67, 0x8f, 0xa0, 0xa3, 0xb0, 0xca, 0x6a, 0xda, 0x78, 0x3a, 0x47, 0x1d, 0x19, 0x26, 0x7c,
+	0x17, 0xc0, 0xe0, 0x3b, 0x9e, 0xc5, 0x3c, 0xdb, 0x6c, 0x75, 0xf4, 0x10, 0xa0, 0xb5, 0xcc, 0x9d,
+	0xb7, 0x16, 0xf2, 0x11, 0xe5, 0x22, 0xd0, 0x55, 0xa8, 0x15, 0xed, 0xba, 0x39, 0x33, 0x67, 0x53,
+	0x10, 0x1f, 0x44, 0x5d, 0x90, 0x95, 0x93, 0xdb, 0x05, 0xf1, 0x2a, 0x51, 0xc5, 0x85, 0x6f, 0x60,
+	0xe0, 0x17, 0x96, 0x69, 0x2a, 0x33, 0x71, 0x4a, 0xb3, 0x34, 0xb7, 0x09, 0x74, 0xa2, 0x06, 0x30,
+	0x2d, 0x12, 0x63, 0x82, 0xba, 0xf9, 0x4d, 0xf1, 0xa2, 0x9b, 0x7a, 0xcd, 0x6c, 0xbc, 0x93, 0xc8,
+	0x9e, 0x57, 0x7d, 0xfb, 0xf3, 0xf3, 0xe4, 0xff, 0x01, 0x00, 0x31, 0x8b, 0xcb, 0xc6, 0x0b, 0x09,
+	0x00, 0x00,
 }
@@ -0,0 +1,73 @@
+package policy
+
+import (
+	"fmt"
+)
+
+const (
+	// AnnotationsMatchAny requires that any of annotations of rule is present (default)
+	AnnotationsMatchAny = "any"
+
+	// AnnotationsMatchAll requires that all of annotations of rule are present
+	AnnotationsMatchAll = "all"
+)
+
+// ValidateAnnotationsMatch checks if semantics of annotations matching is supported
+func ValidateAnnotationsMatch(annotationsMatch string) error {
+	switch annotationsMatch {
+	case "", AnnotationsMatchAny, AnnotationsMatchAll:
+		return nil
+	}
+
+	return fmt.Errorf("Annotations match %s is not supported (%s, %s)", annotationsMatch, AnnotationsMatchAny, AnnotationsMatchAll)
+}
+
+// checkAnnotations checks if annotations of the request fulfill annotations of the rule.
+// None of forbidden annotations can be present, and any or all (according to AnnotationsMatch)
+// of annotations of the rule have to be present.
+func checkAnnotations(cr *compiledRule, annotations map[string]string) bool {
+	for _, am := range cr.forbiddenAnnotations {
+		if am.present(annotations) {
+			return false
+		}
+	}
+
+	if cr.rule.AnnotationsMatch == AnnotationsMatchAll {
+		for _, am := range cr.annotations {
+			if !am.present(annotations) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// in the case when in rule is lack of annotations then it matches only to request without annotations,
+	// unless the rule has forbidden annotations
+	if len(cr.annotations) == 0 {
+		return len(annotations) == 0 || len(cr.forbiddenAnnotations) != 0
+	}
+
+	for _, am := range cr.annotations {
+		if am.present(annotations) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// present checks if any of annotations matches to key and value patterns.
+// The empty value pattern means that only presence of key is checked.
+func (am annotationMatcher) present(annotations map[string]string) bool {
+	for reqKey, reqValue := range annotations {
+		if !am.key.match(reqKey) {
+			continue
+		}
+
+		if am.value == nil || am.value.match(reqValue) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,142 @@
+package policy
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/types"
+)
+
+const (
+	// DefaultBreakGlassAnnotation is a key of annotation which contains ticket ID of emergency deploy
+	DefaultBreakGlassAnnotation = "break-glass.image-policy.k8s.io/ticket"
+
+	// DefaultBreakGlassTicketPattern is a pattern which ticket ID has to match to
+	DefaultBreakGlassTicketPattern = `^[A-Z][A-Z0-9]*-[0-9]+$`
+)
+
+// breakGlass contains settings of break-glass with patterns compiled once per rule set
+type breakGlass struct {
+	enabled    bool
+	annotation string
+
+	// ticketPattern is nil in the case when the pattern cannot be compiled
+	pattern       string
+	ticketPattern *regexp.Regexp
+
+	// namespaces are empty in the case when break-glass is enabled in all namespaces
+	namespaces []*regexp.Regexp
+}
+
+// compileBreakGlass compiles patterns of break-glass settings. Namespace patterns
+// which cannot be compiled are left out, the invalid ticket pattern does not
+// match to any ticket.
+func compileBreakGlass() (*breakGlass, []error) {
+	var errors []error
+
+	b := &breakGlass{
+		enabled:    viper.GetBool("server.break_glass.enabled"),
+		annotation: viper.GetString("server.break_glass.annotation"),
+		pattern:    viper.GetString("server.break_glass.ticket_pattern"),
+	}
+	if !b.enabled {
+		return b, nil
+	}
+
+	var err error
+	b.ticketPattern, err = regexp.Compile(b.pattern)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("Break-glass: invalid ticket pattern %s: %s", b.pattern, err))
+	}
+
+	for _, pattern := range viper.GetStringSlice("server.break_glass.namespaces") {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("Break-glass: invalid namespace pattern %s: %s", pattern, err))
+			continue
+		}
+		b.namespaces = append(b.namespaces, re)
+	}
+
+	return b, errors
+}
+
+// ticket returns ticket ID from break-glass annotation of the request.
+// The empty ticket and nil error mean that break-glass is disabled or not requested.
+// The error is returned in the case when break-glass is requested but cannot be used.
+func (b *breakGlass) ticket(req *types.ImageReview) (string, error) {
+	if b == nil || !b.enabled {
+		return "", nil
+	}
+
+	ticket, ok := req.Spec.Annotations[b.annotation]
+	if !ok {
+		return "", nil
+	}
+
+	if !b.enabledIn(req.Spec.Namespace) {
+		return "", fmt.Errorf("break-glass is disabled in namespace %s", req.Spec.Namespace)
+	}
+
+	if b.ticketPattern == nil || !b.ticketPattern.MatchString(ticket) {
+		return "", fmt.Errorf("break-glass ticket \"%s\" does not match to %s", ticket, b.pattern)
+	}
+
+	return ticket, nil
+}
+
+// enabledIn checks if break-glass is enabled in the namespace.
+// In the case when list of namespaces is empty then break-glass is enabled in all of them.
+func (b *breakGlass) enabledIn(namespace string) bool {
+	if len(b.namespaces) == 0 {
+		return true
+	}
+
+	for _, re := range b.namespaces {
+		if re.MatchString(namespace) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// without returns the request without break-glass annotation,
+// so that the annotation does not change which rules match to the request
+func (b *breakGlass) without(req *types.ImageReview) *types.ImageReview {
+	if b == nil || !b.enabled {
+		return req
+	}
+	if _, ok := req.Spec.Annotations[b.annotation]; !ok {
+		return req
+	}
+
+	result := *req
+	result.Spec.Annotations = make(map[string]string)
+	for k, v := range req.Spec.Annotations {
+		if k != b.annotation {
+			result.Spec.Annotations[k] = v
+		}
+	}
+
+	return &result
+}
+
+// recordBreakGlass records use of break-glass for denied images
+func (r *reviewer) recordBreakGlass(ticket, namespace string, denied, reasons []string) {
+	for _, image := range denied {
+		log.Printf("Break-glass: ticket %s overrides deny of image %s in namespace %s\n", ticket, image, namespace)
+	}
+
+	if r.auditAnnotations == nil {
+		r.auditAnnotations = make(map[string]string)
+	}
+	r.auditAnnotations["break-glass"] = fmt.Sprintf("ticket %s: %s", ticket, strings.Join(reasons, "; "))
+
+	if r.trace != nil {
+		r.trace.BreakGlass = ticket
+	}
+}
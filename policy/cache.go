@@ -0,0 +1,317 @@
+package policy
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/store"
+	"github.com/tczekajlo/kir/types"
+)
+
+// watchRetry is a delay before the watch of store is started again
+const watchRetry = time.Second
+
+// Sources of rules of the cache
+const (
+	// SourceStore means that rules are loaded from the store
+	SourceStore = "store"
+
+	// SourceSnapshot means that rules are loaded from the last-known-good snapshot
+	// because the store cannot be reached
+	SourceSnapshot = "snapshot"
+)
+
+// Cache keeps objects of the store in memory together with the rule set compiled
+// from them, thus reviews do not query the store. Changes of stores which can be
+// watched are applied as they come, other stores are loaded again every resync.
+// Every time rules change, they are written to the last-known-good snapshot,
+// which is used in the case when the store cannot be reached at startup.
+type Cache struct {
+	open         func() (store.Store, error)
+	resync       time.Duration
+	snapshotFile string
+
+	// store, snapshot and loaded are changed only by the goroutine which follows the store,
+	// store is nil until it is opened
+	store    store.Store
+	snapshot *store.Snapshot
+	loaded   bool
+
+	mutex    sync.RWMutex
+	ruleSet  *RuleSet
+	source   string
+	revision int64
+	watched  bool
+	watching bool
+	reloads  int
+
+	// synced is the time when the cache was confirmed to be up to date with the store
+	synced time.Time
+}
+
+// NewCache creates cache of objects of the store which is opened by open.
+// The empty snapshotFile means that the last-known-good snapshot is not kept.
+func NewCache(open func() (store.Store, error), resync time.Duration, snapshotFile string) *Cache {
+	return &Cache{open: open, resync: resync, snapshotFile: snapshotFile}
+}
+
+// Run loads objects of the store and keeps them up to date until stop is closed.
+// In the case when the store cannot be reached, rules of the last-known-good snapshot
+// are used until the store is loaded.
+func (c *Cache) Run(stop <-chan struct{}) {
+	if err := c.load(); err != nil {
+		log.Println("Cannot load rules from store:", err)
+		c.loadLastKnownGood()
+	}
+
+	go c.run(stop)
+}
+
+// RuleSet returns the current rule set, it is nil in the case when rules
+// are loaded neither from the store nor from the last-known-good snapshot
+func (c *Cache) RuleSet() *RuleSet {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.ruleSet
+}
+
+// Status reports source, revision and staleness of the cache
+func (c *Cache) Status() *types.CacheStatus {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	status := &types.CacheStatus{
+		Source:   c.source,
+		Revision: c.revision,
+		Stale:    c.source != SourceStore || (c.watched && !c.watching),
+		Reloads:  c.reloads,
+	}
+
+	if !c.synced.IsZero() {
+		synced := c.synced
+		staleness := time.Since(synced)
+
+		status.SyncedAt = &synced
+		status.Staleness = staleness.String()
+		status.Stale = status.Stale || staleness > 2*c.resync
+	}
+
+	return status
+}
+
+// run keeps the cache up to date. Stores which can be watched are followed,
+// other stores and stores which cannot be reached are loaded every resync.
+func (c *Cache) run(stop <-chan struct{}) {
+	for {
+		delay := c.resync
+
+		ws, watched := c.store.(store.WatchStore)
+		if c.loaded && watched {
+			if c.follow(ws, stop) {
+				continue
+			}
+			delay = watchRetry
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(delay):
+		}
+
+		if !c.loaded || !watched {
+			if err := c.load(); err != nil {
+				log.Println("Cannot load rules from store:", err)
+			}
+		}
+	}
+}
+
+// load opens the store in the case when it is not opened yet, loads all objects
+// and compiles them into a new rule set
+func (c *Cache) load() error {
+	c.loaded = false
+
+	if c.store == nil {
+		s, err := c.open()
+		if err != nil {
+			return err
+		}
+		c.store = s
+	}
+
+	snapshot, err := store.Load(c.store)
+	if err != nil {
+		return err
+	}
+	c.snapshot = snapshot
+	c.loaded = true
+
+	_, watched := c.store.(store.WatchStore)
+	c.set(NewRuleSet(sourceOf(snapshot)), snapshot.Revision, SourceStore, time.Now(), watched)
+	c.saveLastKnownGood()
+
+	return nil
+}
+
+// set replaces the rule set, the set of snapshot replaces the previous one of store
+// in the case when objects are loaded again, e.g. after compaction
+func (c *Cache) set(ruleSet *RuleSet, revision int64, source string, synced time.Time, watched bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.source == SourceStore && source == SourceStore {
+		c.reloads++
+	}
+	c.ruleSet = ruleSet
+	c.revision = revision
+	c.source = source
+	c.synced = synced
+	c.watched = watched
+}
+
+// loadLastKnownGood loads rules from the last-known-good snapshot
+func (c *Cache) loadLastKnownGood() {
+	if c.snapshotFile == "" {
+		return
+	}
+
+	snapshot, saved, err := store.ReadSnapshot(c.snapshotFile)
+	if err != nil {
+		log.Println("Cannot load last-known-good snapshot of rules:", err)
+		return
+	}
+
+	log.Printf("Rules are loaded from last-known-good snapshot %s saved at %s\n", c.snapshotFile, saved.Format(time.RFC3339))
+	c.set(NewRuleSet(sourceOf(snapshot)), snapshot.Revision, SourceSnapshot, saved, false)
+}
+
+// saveLastKnownGood writes objects loaded from the store to the last-known-good snapshot
+func (c *Cache) saveLastKnownGood() {
+	if c.snapshotFile == "" {
+		return
+	}
+
+	if err := store.WriteSnapshot(c.snapshotFile, c.snapshot); err != nil {
+		log.Println("Cannot save last-known-good snapshot of rules:", err)
+	}
+}
+
+// follow applies changes of the store until the watch ends, and every resync confirms
+// that the store can be reached, thus changes are not missed as long as the watch runs.
+// In the case when changes are compacted, they are lost, thus all objects are loaded
+// again and the watch is started from the revision at which they are loaded.
+// It returns true in that case.
+func (c *Cache) follow(ws store.WatchStore, stop <-chan struct{}) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ticker := time.NewTicker(c.resync)
+	defer ticker.Stop()
+
+	c.setWatching(true)
+	defer c.setWatching(false)
+
+	watch := ws.Watch(ctx, c.snapshot.Revision)
+	for {
+		select {
+		case <-stop:
+			return false
+		case <-ticker.C:
+			c.check(ws)
+			continue
+		case resp, ok := <-watch:
+			if !ok {
+				return false
+			}
+
+			switch {
+			case resp.Compacted:
+				log.Printf("Changes of store after revision %d are compacted, rules are loaded again\n", c.snapshot.Revision)
+				if err := c.load(); err != nil {
+					log.Println("Cannot load rules from store:", err)
+					return false
+				}
+				return true
+			case resp.Err != nil:
+				log.Println("Watch of store failed:", resp.Err)
+				return false
+			}
+
+			c.apply(resp)
+		}
+	}
+}
+
+// apply applies changes of objects and compiles a new rule set in the case when
+// anything changed. Progress notifications only confirm that the cache is up to date.
+func (c *Cache) apply(resp *store.WatchResponse) {
+	var ruleSet *RuleSet
+
+	if len(resp.Events) != 0 {
+		c.snapshot.Apply(resp.Events)
+		ruleSet = NewRuleSet(sourceOf(c.snapshot))
+	}
+	if resp.Revision > c.snapshot.Revision {
+		c.snapshot.Revision = resp.Revision
+	}
+
+	c.mutex.Lock()
+	if ruleSet != nil {
+		c.ruleSet = ruleSet
+	}
+	c.revision = c.snapshot.Revision
+	c.synced = time.Now()
+	c.mutex.Unlock()
+
+	if ruleSet != nil {
+		c.saveLastKnownGood()
+	}
+}
+
+// check confirms that the cache is up to date in the case when the store can be reached
+func (c *Cache) check(ws store.WatchStore) {
+	if _, err := ws.Revision(); err != nil {
+		log.Println("Cannot reach store:", err)
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.synced = time.Now()
+}
+
+func (c *Cache) setWatching(watching bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.watching = watching
+}
+
+// sourceOf returns objects of the snapshot sorted by name
+func sourceOf(snapshot *store.Snapshot) *Source {
+	src := &Source{Errors: snapshot.ErrorList()}
+
+	for _, item := range snapshot.List(store.Rule) {
+		src.Rules = append(src.Rules, item.(*pb.Rule))
+	}
+	for _, item := range snapshot.List(store.Default) {
+		src.Defaults = append(src.Defaults, item.(*pb.Default))
+	}
+	for _, item := range snapshot.List(store.Policy) {
+		src.Policies = append(src.Policies, item.(*pb.Policy))
+	}
+	for _, item := range snapshot.List(store.Set) {
+		src.Sets = append(src.Sets, item.(*pb.PolicySet))
+	}
+	for _, item := range snapshot.List(store.Binding) {
+		src.Bindings = append(src.Bindings, item.(*pb.Binding))
+	}
+
+	return src
+}
@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"github.com/tczekajlo/kir/types"
+)
+
+// conditionEnv declares variables available in conditions of rules:
+//
+// request - spec of the request: namespace, annotations and containers (list of images)
+// image   - the reviewed container: image, registry, repository, tag and digest
+//
+// The namespace is a reserved word in CEL, thus it cannot be a variable itself.
+var conditionEnv, conditionEnvErr = cel.NewEnv(
+	cel.Variable("request", cel.MapType(cel.StringType, cel.DynType)),
+	cel.Variable("image", cel.MapType(cel.StringType, cel.StringType)),
+	ext.Strings(),
+)
+
+// compileCondition compiles and type-checks the condition, which has to return bool
+func compileCondition(condition string) (cel.Program, error) {
+	if conditionEnvErr != nil {
+		return nil, conditionEnvErr
+	}
+
+	ast, issues := conditionEnv.Compile(condition)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("Invalid condition: %s", issues.Err())
+	}
+
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("Invalid condition: expression returns %s instead of bool", ast.OutputType())
+	}
+
+	program, err := conditionEnv.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid condition: %s", err)
+	}
+
+	return program, nil
+}
+
+// checkCondition evaluates condition of the rule for the container.
+// The rule without condition always matches, errors of evaluation mean that the rule does not match.
+func checkCondition(cr *compiledRule, reqContainer types.ImageReviewContainerSpec, ref *Reference, req *types.ImageReview) bool {
+	if cr.condition == nil {
+		return true
+	}
+
+	out, _, err := cr.condition.Eval(conditionVars(reqContainer, ref, req))
+	if err != nil {
+		log.Printf("Rule %s: cannot evaluate condition: %s\n", cr.rule.Name, err)
+		return false
+	}
+
+	result, ok := out.Value().(bool)
+	return ok && result
+}
+
+// conditionVars returns values of variables available in conditions
+func conditionVars(reqContainer types.ImageReviewContainerSpec, ref *Reference, req *types.ImageReview) map[string]interface{} {
+	containers := []string{}
+	for _, container := range req.Spec.Containers {
+		containers = append(containers, container.Image)
+	}
+
+	annotations := req.Spec.Annotations
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	// parts are empty in the case when the image cannot be parsed
+	if ref == nil {
+		ref = &Reference{}
+	}
+	image := map[string]string{
+		"image":      reqContainer.Image,
+		"registry":   ref.Registry,
+		"repository": ref.Repository,
+		"tag":        ref.Tag,
+		"digest":     ref.Digest,
+	}
+
+	return map[string]interface{}{
+		"request": map[string]interface{}{
+			"namespace":   req.Spec.Namespace,
+			"annotations": annotations,
+			"containers":  containers,
+		},
+		"image": image,
+	}
+}
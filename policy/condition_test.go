@@ -0,0 +1,87 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/types"
+)
+
+func TestCondition(t *testing.T) {
+	req := &types.ImageReview{Spec: types.ImageReviewSpec{
+		Namespace:   "team-a",
+		Annotations: map[string]string{"ticket.image-policy.k8s.io/id": "1"},
+		Containers:  []types.ImageReviewContainerSpec{{Image: "a.registry.example.com/app:v1"}, {Image: "nginx"}},
+	}}
+	container := req.Spec.Containers[0]
+	ref, err := ParseReference(container.Image)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(condition string) bool {
+		cr, err := compileRule(&pb.Rule{Name: "condition", Condition: condition})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return checkCondition(cr, container, ref, req)
+	}
+
+	for _, condition := range []string{
+		`image.registry == request.namespace.replace("team-", "") + ".registry.example.com"`,
+		`"ticket.image-policy.k8s.io/id" in request.annotations && image.tag != "latest"`,
+		`size(request.containers) == 2`,
+		`image.repository == "app" && image.digest == ""`,
+	} {
+		if !check(condition) {
+			t.Errorf("%s: expected match", condition)
+		}
+	}
+
+	// conditions which are false or cannot be evaluated, e.g. of missing keys, do not match
+	for _, condition := range []string{
+		`"other.image-policy.k8s.io/id" in request.annotations`,
+		`request.namespace.startsWith("prod")`,
+		`request.annotations["missing"] == "x"`,
+	} {
+		if check(condition) {
+			t.Errorf("%s: expected no match", condition)
+		}
+	}
+
+	for _, condition := range []string{"request.namespace", "foo == 1", "image.tag =="} {
+		if err := Validate(&pb.Rule{Name: "condition", Condition: condition}); err == nil {
+			t.Errorf("%s: expected error", condition)
+		}
+	}
+}
+
+func TestConditionReview(t *testing.T) {
+	viper.Set("server.default_allowed", true)
+	defer viper.Set("server.default_allowed", nil)
+
+	// images of teams come only from their own registries
+	s := NewRuleSet(&Source{Rules: []*pb.Rule{{
+		Name:       "team-registry",
+		Namespace:  "^team-",
+		Containers: []*pb.Rule_Containers{{Image: "."}},
+		Condition:  `image.registry != request.namespace.replace("team-", "") + ".registry.example.com"`,
+	}}})
+
+	allowed := s.Review(&types.ImageReview{Spec: types.ImageReviewSpec{
+		Namespace:  "team-a",
+		Containers: []types.ImageReviewContainerSpec{{Image: "a.registry.example.com/app:v1"}},
+	}})
+	if !allowed.Status.Allowed {
+		t.Errorf("image of own registry: got denied: %s", allowed.Status.Reason)
+	}
+
+	denied := s.Review(&types.ImageReview{Spec: types.ImageReviewSpec{
+		Namespace:  "team-a",
+		Containers: []types.ImageReviewContainerSpec{{Image: "b.registry.example.com/app:v1"}},
+	}})
+	if denied.Status.Allowed {
+		t.Error("image of other registry: got allowed")
+	}
+}
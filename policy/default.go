@@ -0,0 +1,53 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/pb"
+)
+
+// DefaultReason is a reason of the default decision in the case when it is not configured
+const DefaultReason = "Cannot match to any rule"
+
+// defaultDecision returns decision for images which do not match to any rule.
+// The first default which matches to the namespace overrides the decision
+// from server configuration.
+func defaultDecision(defaults []*compiledDefault, namespace string) decision {
+	for _, d := range defaults {
+		if d.namespace.match(namespace) {
+			return decision{
+				allowed: d.data.Allowed,
+				reason:  reasonOrDefault(d.data.Reason),
+			}
+		}
+	}
+
+	return decision{
+		allowed: viper.GetBool("server.default_allowed"),
+		reason:  reasonOrDefault(viper.GetString("server.default_reason")),
+	}
+}
+
+// ValidateDefault checks if the namespace pattern of default decision is correct
+func ValidateDefault(data *pb.Default) error {
+	_, err := compileDefault(data)
+	return err
+}
+
+func compileDefault(data *pb.Default) (*compiledDefault, error) {
+	namespace, err := compileMatcher(data.NamespaceMatchType, data.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("Default %s: %s", data.Name, err)
+	}
+
+	return &compiledDefault{data: data, namespace: namespace}, nil
+}
+
+func reasonOrDefault(reason string) string {
+	if reason == "" {
+		return DefaultReason
+	}
+
+	return reason
+}
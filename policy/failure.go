@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// FailClosed denies requests which cannot be reviewed
+	FailClosed = "fail-closed"
+
+	// FailOpen allows requests which cannot be reviewed, the failure is reported
+	// in audit annotations of the response
+	FailOpen = "fail-open"
+
+	// FailureAnnotation is the audit annotation with the reason why
+	// the request allowed by fail-open policy cannot be reviewed
+	FailureAnnotation = "failure"
+)
+
+// ValidateFailurePolicy checks if failure policy is supported
+func ValidateFailurePolicy(failurePolicy string) error {
+	switch failurePolicy {
+	case FailClosed, FailOpen:
+		return nil
+	}
+
+	return fmt.Errorf("Failure policy %s is not supported (%s, %s)", failurePolicy, FailClosed, FailOpen)
+}
+
+// failureResponse returns decision for the request which cannot be reviewed,
+// e.g. rules are not available, according to the failure policy
+func failureResponse(req *types.ImageReview, err error) *types.ImageReviewResponse {
+	reason := fmt.Sprintf("Cannot review images: %s", err)
+	log.Println(reason)
+
+	status := types.ImageReviewStatus{
+		Allowed: viper.GetString("server.failure_policy") == FailOpen,
+	}
+	if status.Allowed {
+		status.AuditAnnotations = map[string]string{FailureAnnotation: reason}
+	} else {
+		status.Reason = reason
+	}
+
+	return &types.ImageReviewResponse{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       req.TypeMeta.Kind,
+			APIVersion: req.TypeMeta.APIVersion,
+		},
+		Status: status,
+	}
+}
+
+// recoverFailure turns panic during review into decision of the failure policy,
+// thus the API server never gets an error instead of decision
+func recoverFailure(req *types.ImageReview, resp **types.ImageReviewResponse) {
+	if r := recover(); r != nil {
+		*resp = failureResponse(req, fmt.Errorf("%v", r))
+	}
+}
@@ -0,0 +1,108 @@
+package policy
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// NamespaceLabels looks up labels of namespaces
+type NamespaceLabels interface {
+	Labels(namespace string) (map[string]string, error)
+}
+
+var namespaceLabels struct {
+	sync.RWMutex
+	source NamespaceLabels
+}
+
+// SetNamespaceLabels sets source of namespace labels which are matched
+// to namespace selectors of rules
+func SetNamespaceLabels(source NamespaceLabels) {
+	namespaceLabels.Lock()
+	defer namespaceLabels.Unlock()
+
+	namespaceLabels.source = source
+}
+
+// lookupNamespaceLabels returns labels of the namespace. In the case when labels
+// cannot be looked up then rules with namespace selector do not match.
+func lookupNamespaceLabels(namespace string) (labels.Set, error) {
+	namespaceLabels.RLock()
+	defer namespaceLabels.RUnlock()
+
+	if namespaceLabels.source == nil {
+		return nil, fmt.Errorf("labels of namespaces are not available, enable them with --namespace-labels-enabled flag")
+	}
+
+	result, err := namespaceLabels.source.Labels(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot get labels of namespace %s: %s", namespace, err)
+	}
+
+	// a namespace without labels still matches to selectors like "!env"
+	if result == nil {
+		result = map[string]string{}
+	}
+
+	return labels.Set(result), nil
+}
+
+// compileNamespaceSelector parses label selector, e.g. "env=prod,team in (payments,risk)".
+// It returns nil selector in the case when the selector is empty.
+func compileNamespaceSelector(selector string) (labels.Selector, error) {
+	if selector == "" {
+		return nil, nil
+	}
+
+	result, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid namespace selector %s: %s", selector, err)
+	}
+
+	return result, nil
+}
+
+// checkNamespaceSelector checks if labels of namespace match to the selector of the rule.
+// The nil labels mean that labels cannot be looked up.
+func checkNamespaceSelector(cr *compiledRule, nsLabels labels.Set) bool {
+	if cr.namespaceSelector == nil {
+		return true
+	}
+
+	if nsLabels == nil {
+		return false
+	}
+
+	return cr.namespaceSelector.Matches(nsLabels)
+}
+
+// hasNamespaceSelector checks if any of rules has namespace selector,
+// thus labels of namespace have to be looked up
+func hasNamespaceSelector(rules []*compiledRule) bool {
+	for _, cr := range rules {
+		if cr.namespaceSelector != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reviewNamespaceLabels returns labels of the namespace of request in the case when
+// any of rules needs them
+func reviewNamespaceLabels(rules []*compiledRule, namespace string) labels.Set {
+	if !hasNamespaceSelector(rules) {
+		return nil
+	}
+
+	result, err := lookupNamespaceLabels(namespace)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	return result
+}
@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MatchRegex matches a value to unanchored regex (default)
+	MatchRegex = "regex"
+
+	// MatchAnchoredRegex matches a whole value to regex
+	MatchAnchoredRegex = "anchored-regex"
+
+	// MatchGlob matches a value to shell glob, e.g. team-*
+	MatchGlob = "glob"
+
+	// MatchExact matches a value which is equal to pattern
+	MatchExact = "exact"
+
+	// MatchPrefix matches a value which starts with pattern
+	MatchPrefix = "prefix"
+)
+
+// ValidateMatchType checks if match type is supported
+func ValidateMatchType(matchType string) error {
+	switch matchType {
+	case "", MatchRegex, MatchAnchoredRegex, MatchGlob, MatchExact, MatchPrefix:
+		return nil
+	}
+
+	return fmt.Errorf("Match type %s is not supported (%s, %s, %s, %s, %s)", matchType, MatchRegex, MatchAnchoredRegex, MatchGlob, MatchExact, MatchPrefix)
+}
+
+// matcher is a pattern compiled according to its match type
+type matcher struct {
+	matchType string
+	pattern   string
+	re        *regexp.Regexp
+}
+
+// compileMatcher compiles the pattern using given match type.
+// It returns the exact error in the case when the pattern is invalid.
+func compileMatcher(matchType, pattern string) (*matcher, error) {
+	m := &matcher{matchType: matchType, pattern: pattern}
+
+	var err error
+	switch matchType {
+	case "", MatchRegex:
+		m.re, err = regexp.Compile(pattern)
+	case MatchAnchoredRegex:
+		m.re, err = regexp.Compile("^(?:" + pattern + ")$")
+	case MatchGlob:
+		_, err = path.Match(pattern, "")
+	case MatchExact, MatchPrefix:
+	default:
+		err = ValidateMatchType(matchType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Invalid pattern %s: %s", pattern, err)
+	}
+
+	return m, nil
+}
+
+// match checks if value matches to the pattern
+func (m *matcher) match(value string) bool {
+	switch m.matchType {
+	case MatchGlob:
+		matched, _ := path.Match(m.pattern, value)
+		return matched
+	case MatchExact:
+		return m.pattern == value
+	case MatchPrefix:
+		return strings.HasPrefix(value, m.pattern)
+	}
+
+	return m.re.MatchString(value)
+}
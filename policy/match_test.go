@@ -0,0 +1,61 @@
+package policy
+
+import "testing"
+
+func TestMatchTypes(t *testing.T) {
+	tests := []struct {
+		matchType string
+		pattern   string
+		matches   []string
+		others    []string
+	}{
+		{"", "default", []string{"default", "not-default"}, []string{"kube-system"}},
+		{MatchRegex, "^default$", []string{"default"}, []string{"not-default"}},
+		{MatchAnchoredRegex, "prod|dev", []string{"prod", "dev"}, []string{"preprod", "prod-1"}},
+		{MatchAnchoredRegex, "def.*", []string{"default"}, []string{"not-default"}},
+		{MatchGlob, "team-?", []string{"team-a"}, []string{"team-ab", "my-team-a"}},
+		{MatchGlob, "myrepo/*", []string{"myrepo/a"}, []string{"myrepo/a/b"}},
+		{MatchExact, "default", []string{"default"}, []string{"default-2", "Default"}},
+		{MatchPrefix, "team-", []string{"team-x", "team-"}, []string{"my-team-x"}},
+	}
+
+	for _, test := range tests {
+		m, err := compileMatcher(test.matchType, test.pattern)
+		if err != nil {
+			t.Errorf("%s %s: %s", test.matchType, test.pattern, err)
+			continue
+		}
+
+		for _, value := range test.matches {
+			if !m.match(value) {
+				t.Errorf("%s %s: %s is not matched", test.matchType, test.pattern, value)
+			}
+		}
+		for _, value := range test.others {
+			if m.match(value) {
+				t.Errorf("%s %s: %s is matched", test.matchType, test.pattern, value)
+			}
+		}
+	}
+}
+
+func TestMatchTypesErrors(t *testing.T) {
+	if _, err := compileMatcher(MatchRegex, "(("); err == nil {
+		t.Error("invalid regex: expected error")
+	}
+	if _, err := compileMatcher(MatchAnchoredRegex, "[a-"); err == nil {
+		t.Error("invalid anchored regex: expected error")
+	}
+	if _, err := compileMatcher(MatchGlob, "["); err == nil {
+		t.Error("invalid glob: expected error")
+	}
+
+	// exact and prefix patterns are never invalid
+	if _, err := compileMatcher(MatchExact, "(("); err != nil {
+		t.Errorf("exact: %s", err)
+	}
+
+	if err := ValidateMatchType("wildcard"); err == nil {
+		t.Error("unsupported match type: expected error")
+	}
+}
@@ -0,0 +1,66 @@
+package policy
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/tczekajlo/kir/pb"
+)
+
+const (
+	// ModeEnforce takes decision of the rule into account (default)
+	ModeEnforce = "enforce"
+
+	// ModeWarn does not take decision of the rule into account, but a would-be deny
+	// is reported in audit annotations of the response and in the server log
+	ModeWarn = "warn"
+
+	// ModeAudit does not take decision of the rule into account, but a would-be decision
+	// is recorded in audit annotations of the response
+	ModeAudit = "audit"
+)
+
+// ValidateMode checks if enforcement mode of rule is supported
+func ValidateMode(mode string) error {
+	switch mode {
+	case "", ModeEnforce, ModeWarn, ModeAudit:
+		return nil
+	}
+
+	return fmt.Errorf("Mode %s is not supported (%s, %s, %s)", mode, ModeEnforce, ModeWarn, ModeAudit)
+}
+
+// isEnforced checks if decision of the rule is taken into account
+func isEnforced(rule *pb.Rule) bool {
+	return rule.Mode == "" || rule.Mode == ModeEnforce
+}
+
+// record records would-be decision of the rule which is not enforced
+func (r *reviewer) record(d decision, image string) {
+	var key, value string
+
+	switch d.rule.Mode {
+	case ModeWarn:
+		if d.allowed {
+			return
+		}
+		key, value = "warn-"+d.rule.Name, d.reason
+		log.Printf("Warning: %s\n", d.reason)
+	case ModeAudit:
+		key, value = "audit-"+d.rule.Name, d.reason
+		if d.allowed {
+			value = fmt.Sprintf("%s: allowed by rule \"%s\"", image, d.rule.Name)
+		}
+	default:
+		return
+	}
+
+	if r.auditAnnotations == nil {
+		r.auditAnnotations = make(map[string]string)
+	}
+
+	if previous, ok := r.auditAnnotations[key]; ok {
+		value = previous + "; " + value
+	}
+	r.auditAnnotations[key] = value
+}
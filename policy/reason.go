@@ -0,0 +1,77 @@
+package policy
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"text/template"
+
+	"github.com/tczekajlo/kir/pb"
+)
+
+// reasonData contains data available in the template of reason, e.g.
+// "{{.Image}} is not allowed in {{.Namespace}}, see {{.Metadata.docs}}"
+type reasonData struct {
+	Image      string
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+	Namespace  string
+	Rule       string
+	Metadata   map[string]string
+}
+
+// compileReason parses the reason of rule as a template and checks it
+// by rendering it for a sample image
+func compileReason(rule *pb.Rule) (*template.Template, error) {
+	tmpl, err := template.New(rule.Name).Option("missingkey=zero").Parse(rule.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid reason: %s", err)
+	}
+
+	ref, _ := ParseReference("nginx:latest")
+	if _, err := executeReason(tmpl, rule, "nginx:latest", ref, "default"); err != nil {
+		return nil, fmt.Errorf("Invalid reason: %s", err)
+	}
+
+	return tmpl, nil
+}
+
+// renderReason renders the reason of rule. In the case when the template
+// cannot be rendered then the reason is returned as it is.
+func renderReason(cr *compiledRule, image string, ref *Reference, namespace string) string {
+	if cr.reason == nil {
+		return cr.rule.Reason
+	}
+
+	result, err := executeReason(cr.reason, cr.rule, image, ref, namespace)
+	if err != nil {
+		log.Printf("Rule %s: cannot render reason: %s\n", cr.rule.Name, err)
+		return cr.rule.Reason
+	}
+
+	return result
+}
+
+func executeReason(tmpl *template.Template, rule *pb.Rule, image string, ref *Reference, namespace string) (string, error) {
+	var buf bytes.Buffer
+
+	// parts are empty in the case when the image cannot be parsed
+	if ref == nil {
+		ref = &Reference{}
+	}
+
+	err := tmpl.Execute(&buf, reasonData{
+		Image:      image,
+		Registry:   ref.Registry,
+		Repository: ref.Repository,
+		Tag:        ref.Tag,
+		Digest:     ref.Digest,
+		Namespace:  namespace,
+		Rule:       rule.Name,
+		Metadata:   rule.Metadata,
+	})
+
+	return buf.String(), err
+}
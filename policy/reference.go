@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// DefaultRegistry is a registry of images which are given without registry
+	DefaultRegistry = "docker.io"
+
+	// officialRepository is a namespace of official images on Docker Hub
+	officialRepository = "library"
+)
+
+var digestRegexp = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]{32,}$`)
+
+// Reference contains parts of a container image
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses an image in the form [registry/]repository[:tag][@digest].
+// Images from Docker Hub are normalised, e.g. nginx is parsed as docker.io/library/nginx.
+// The tag is left empty in the case when an image does not contain it.
+func ParseReference(image string) (*Reference, error) {
+	ref := &Reference{}
+	name := image
+
+	if i := strings.Index(name, "@"); i != -1 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+
+		if !digestRegexp.MatchString(ref.Digest) {
+			return nil, fmt.Errorf("Invalid digest in image %s", image)
+		}
+	}
+
+	// a colon after the last slash separates the tag, otherwise it is a port of registry
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+
+		if ref.Tag == "" {
+			return nil, fmt.Errorf("Invalid tag in image %s", image)
+		}
+	}
+
+	ref.Registry = DefaultRegistry
+	if i := strings.Index(name, "/"); i != -1 {
+		if host := name[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.Registry = host
+			name = name[i+1:]
+		}
+	}
+	if ref.Registry == "index.docker.io" {
+		ref.Registry = DefaultRegistry
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("Invalid repository in image %s", image)
+	}
+	if ref.Registry == DefaultRegistry && !strings.Contains(name, "/") {
+		name = officialRepository + "/" + name
+	}
+	ref.Repository = name
+
+	return ref, nil
+}
+
+// String returns the normalised image
+func (r *Reference) String() string {
+	result := r.Registry + "/" + r.Repository
+
+	if r.Tag != "" {
+		result += ":" + r.Tag
+	}
+
+	if r.Digest != "" {
+		result += "@" + r.Digest
+	}
+
+	return result
+}
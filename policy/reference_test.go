@@ -0,0 +1,74 @@
+package policy
+
+import "testing"
+
+const testDigest = "sha256:beb6bd6a68f114c1dc2ea4b28db81bdf91de202a9014972bec5e4d9171d90ed"
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		image      string
+		registry   string
+		repository string
+		tag        string
+		digest     string
+		normalised string
+	}{
+		// implicit docker.io registry and library namespace of official images
+		{"nginx", "docker.io", "library/nginx", "", "", "docker.io/library/nginx"},
+		{"nginx:1.19", "docker.io", "library/nginx", "1.19", "", "docker.io/library/nginx:1.19"},
+		{"docker.io/library/nginx:latest", "docker.io", "library/nginx", "latest", "", "docker.io/library/nginx:latest"},
+		{"index.docker.io/foo/bar", "docker.io", "foo/bar", "", "", "docker.io/foo/bar"},
+		{"myrepo/myimage:v1", "docker.io", "myrepo/myimage", "v1", "", "docker.io/myrepo/myimage:v1"},
+
+		// registry with port is not taken as a tag
+		{"localhost:5000/app", "localhost:5000", "app", "", "", "localhost:5000/app"},
+		{"registry.example.com:5000/team/app:2.0", "registry.example.com:5000", "team/app", "2.0", "", "registry.example.com:5000/team/app:2.0"},
+		{"localhost/app:x", "localhost", "app", "x", "", "localhost/app:x"},
+
+		// digest with and without tag
+		{"gcr.io/proj/app@" + testDigest, "gcr.io", "proj/app", "", testDigest, "gcr.io/proj/app@" + testDigest},
+		{"gcr.io/proj/app:v1@" + testDigest, "gcr.io", "proj/app", "v1", testDigest, "gcr.io/proj/app:v1@" + testDigest},
+		{"nginx@" + testDigest, "docker.io", "library/nginx", "", testDigest, "docker.io/library/nginx@" + testDigest},
+	}
+
+	for _, test := range tests {
+		ref, err := ParseReference(test.image)
+		if err != nil {
+			t.Errorf("%s: %s", test.image, err)
+			continue
+		}
+
+		if ref.Registry != test.registry || ref.Repository != test.repository || ref.Tag != test.tag || ref.Digest != test.digest {
+			t.Errorf("%s: got %+v, want registry %s, repository %s, tag %s, digest %s",
+				test.image, *ref, test.registry, test.repository, test.tag, test.digest)
+		}
+
+		if ref.String() != test.normalised {
+			t.Errorf("%s: got %s, want %s", test.image, ref, test.normalised)
+		}
+	}
+}
+
+func TestParseReferenceErrors(t *testing.T) {
+	tests := []struct {
+		image string
+		err   string
+	}{
+		{"", "Invalid repository in image "},
+		{"nginx:", "Invalid tag in image nginx:"},
+		{"myrepo/myimage@sha256:beb6", "Invalid digest in image myrepo/myimage@sha256:beb6"},
+		{"gcr.io/", "Invalid repository in image gcr.io/"},
+	}
+
+	for _, test := range tests {
+		ref, err := ParseReference(test.image)
+		if err == nil {
+			t.Errorf("%s: got %+v, want error", test.image, *ref)
+			continue
+		}
+
+		if err.Error() != test.err {
+			t.Errorf("%s: got error %q, want %q", test.image, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/types"
+)
+
+const (
+	// RegoPackage is a package which Rego policies have to be defined in
+	RegoPackage = "kir"
+
+	// RegoQuery is a query which returns set of deny messages of Rego policies
+	RegoQuery = "data." + RegoPackage + ".deny"
+)
+
+// ValidatePolicy checks if Rego module of the policy is defined in kir package and compiles
+// together with stored policies, which are evaluated together with it. The stored policy
+// of the same name is replaced by the policy.
+func ValidatePolicy(policy *pb.Policy, stored []*pb.Policy) error {
+	module, err := ast.ParseModule(policy.Name, policy.Module)
+	if err != nil {
+		return fmt.Errorf("Policy %s: %s", policy.Name, err)
+	}
+
+	if module == nil {
+		return fmt.Errorf("Policy %s: Rego module is empty", policy.Name)
+	}
+
+	if path := module.Package.Path.String(); path != "data."+RegoPackage {
+		return fmt.Errorf("Policy %s: Rego module has to be in package %s instead of %s", policy.Name, RegoPackage, path)
+	}
+
+	if _, err := ast.CompileModules(map[string]string{policy.Name: policy.Module}); err != nil {
+		return fmt.Errorf("Policy %s: %s", policy.Name, err)
+	}
+
+	policies := []*pb.Policy{policy}
+	for _, data := range stored {
+		if data.Name != policy.Name {
+			policies = append(policies, data)
+		}
+	}
+
+	if _, err := compilePolicies(policies); err != nil {
+		return fmt.Errorf("Policy %s conflicts with stored policies: %s", policy.Name, err)
+	}
+
+	return nil
+}
+
+// compilePolicies compiles Rego policies together and prepares the query of deny messages,
+// thus it is not prepared for every request. It returns nil query in the case when
+// there are no policies.
+func compilePolicies(policies []*pb.Policy) (*rego.PreparedEvalQuery, error) {
+	if len(policies) == 0 {
+		return nil, nil
+	}
+
+	modules := make(map[string]string)
+	for _, policy := range policies {
+		modules[policy.Name] = policy.Module
+	}
+
+	compiler, err := ast.CompileModules(modules)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot compile Rego policies: %s", err)
+	}
+
+	query, err := rego.New(
+		rego.Query(RegoQuery),
+		rego.Compiler(compiler),
+	).PrepareForEval(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("Cannot prepare Rego policies: %s", err)
+	}
+
+	return &query, nil
+}
+
+// evalPolicies evaluates Rego policies with the request as input and returns deny messages.
+// In the case when policies cannot be compiled or evaluated the request is denied.
+func (s *RuleSet) evalPolicies(req *types.ImageReview) []string {
+	var result []string
+
+	if s.regoErr != nil {
+		return []string{s.regoErr.Error()}
+	}
+
+	if s.rego == nil {
+		return nil
+	}
+
+	rs, err := s.rego.Eval(context.Background(), rego.EvalInput(req))
+	if err != nil {
+		log.Println("Cannot evaluate Rego policies:", err)
+		return []string{fmt.Sprintf("Cannot evaluate Rego policies: %s", err)}
+	}
+
+	for _, r := range rs {
+		for _, expression := range r.Expressions {
+			messages, ok := expression.Value.([]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, message := range messages {
+				result = append(result, fmt.Sprintf("%v", message))
+			}
+		}
+	}
+
+	return result
+}
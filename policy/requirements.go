@@ -0,0 +1,59 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tczekajlo/kir/pb"
+)
+
+// DefaultMutableTags contains tags which are treated as mutable
+// in the case when a rule does not define them
+var DefaultMutableTags = []string{"latest"}
+
+// checkRequirements checks if the image fulfills requirements of the rule and its container
+// which matched to the image.
+// It returns description of the first unfulfilled requirement or empty string.
+func checkRequirements(rule *pb.Rule, container *pb.Rule_Containers, ref *Reference) string {
+	if violation := checkSemverRequirement(container, ref); violation != "" {
+		return violation
+	}
+
+	if !rule.RequireDigest && !rule.DenyMutableTags {
+		return ""
+	}
+
+	if ref == nil {
+		return "image cannot be parsed"
+	}
+
+	if rule.RequireDigest {
+		if ref.Digest == "" {
+			return "image is not pinned by digest, use image@sha256:<digest>"
+		}
+
+		if !strings.HasPrefix(ref.Digest, "sha256:") {
+			return fmt.Sprintf("digest %s is not sha256 digest", ref.Digest)
+		}
+	}
+
+	// an image pinned by digest is immutable regardless of its tag
+	if rule.DenyMutableTags && ref.Digest == "" {
+		if ref.Tag == "" {
+			return "image has no tag"
+		}
+
+		mutableTags := rule.MutableTags
+		if len(mutableTags) == 0 {
+			mutableTags = DefaultMutableTags
+		}
+
+		for _, tag := range mutableTags {
+			if ref.Tag == tag {
+				return fmt.Sprintf("tag %s is mutable", ref.Tag)
+			}
+		}
+	}
+
+	return ""
+}
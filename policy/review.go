@@ -1,40 +1,220 @@
 package policy
 
 import (
+	"fmt"
 	"log"
-	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/tczekajlo/kir/etcd"
+	"github.com/spf13/viper"
 	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/store"
 	"github.com/tczekajlo/kir/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
-// Review makes image review and returns data of matched rule
-func Review(req *types.ImageReview) *types.ImageReviewResponse {
+const (
+	// FirstMatch takes decision of the matched rule with the highest priority
+	FirstMatch = "first-match"
+
+	// DenyOverrides denies an image if any of matched rules denies it
+	DenyOverrides = "deny-overrides"
+
+	// AllowOverrides allows an image if any of matched rules allows it
+	AllowOverrides = "allow-overrides"
+)
+
+// ValidateStrategy checks if strategy of conflict resolution is supported
+func ValidateStrategy(strategy string) error {
+	switch strategy {
+	case FirstMatch, DenyOverrides, AllowOverrides:
+		return nil
+	}
+
+	return fmt.Errorf("Conflict strategy %s is not supported (%s, %s, %s)", strategy, FirstMatch, DenyOverrides, AllowOverrides)
+}
+
+// reviewer contains data needed to make review of containers
+type reviewer struct {
+	rules    []*compiledRule
+	strategy string
+	now      time.Time
+
+	// fallback is a decision for containers which do not match to any rule
+	fallback decision
+
+	// auditAnnotations contains would-be decisions of rules which are not enforced
+	auditAnnotations map[string]string
+
+	// namespaceLabels are labels of namespace of the request,
+	// nil in the case when none of rules needs them or they cannot be looked up
+	namespaceLabels labels.Set
+
+	// trace is filled in only in the case when explanation of the decision is requested
+	trace *types.ReviewTrace
+}
+
+// Review makes image review of the request using rules kept in the store.
+// In the case when rules are not available the decision is taken according
+// to the failure policy.
+func Review(req *types.ImageReview) (resp *types.ImageReviewResponse) {
+	defer recoverFailure(req, &resp)
+
+	s, err := currentRuleSet()
+	if err != nil {
+		return failureResponse(req, err)
+	}
+
+	return s.Review(req)
+}
+
+// Explain makes image review like Review and additionally returns trace
+// of the decision, i.e. results of every rule for every container.
+func Explain(req *types.ImageReview) (resp *types.ImageReviewResponse) {
+	defer recoverFailure(req, &resp)
+
+	s, err := currentRuleSet()
+	if err != nil {
+		return failureResponse(req, err)
+	}
+
+	return s.Explain(req)
+}
+
+// Status reports state of rules kept in the store, e.g. rules which cannot be compiled,
+// and state of the cache in the case when it is used
+func Status() *types.RuleSetStatus {
+	status := &types.RuleSetStatus{}
+
+	s, err := currentRuleSet()
+	if err != nil {
+		status.Errors = []string{err.Error()}
+	} else {
+		status = s.Status()
+	}
+
+	if c := currentCache(); c != nil {
+		status.Cache = c.Status()
+	}
+
+	return status
+}
+
+var cache struct {
+	sync.RWMutex
+	current *Cache
+}
+
+// SetCache sets cache of rules which is used during review instead of loading
+// rules from the store for every request
+func SetCache(c *Cache) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	cache.current = c
+}
+
+func currentCache() *Cache {
+	cache.RLock()
+	defer cache.RUnlock()
+
+	return cache.current
+}
+
+// currentRuleSet returns rule set of the cache, or loads it from the store
+// in the case when there is no cache
+func currentRuleSet() (*RuleSet, error) {
+	c := currentCache()
+	if c == nil {
+		return loadRuleSet()
+	}
+
+	if s := c.RuleSet(); s != nil {
+		return s, nil
+	}
+
+	return nil, fmt.Errorf("Rules are not available, neither the store nor the last-known-good snapshot can be read")
+}
+
+// loadRuleSet creates snapshot of rules, defaults and Rego policies kept in the store
+func loadRuleSet() (*RuleSet, error) {
+	storage, err := store.New()
+	if err != nil {
+		return nil, err
+	}
+	defer storage.Close()
+
+	snapshot, err := store.Load(storage)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot load rules: %s", err)
+	}
+
+	return NewRuleSet(sourceOf(snapshot)), nil
+}
+
+// Review makes image review of every container in the request.
+// The request is allowed only if all of containers are allowed and none of Rego policies
+// denies it, or denies are overridden by break-glass annotation.
+func (s *RuleSet) Review(req *types.ImageReview) *types.ImageReviewResponse {
+	return s.review(req, false)
+}
+
+// Explain makes image review like Review and additionally returns trace of the decision.
+func (s *RuleSet) Explain(req *types.ImageReview) *types.ImageReviewResponse {
+	return s.review(req, true)
+}
+
+func (s *RuleSet) review(req *types.ImageReview, trace bool) *types.ImageReviewResponse {
+	var reasons, denied []string
+
 	meta := metav1.TypeMeta{
 		Kind:       req.TypeMeta.Kind,
 		APIVersion: req.TypeMeta.APIVersion,
 	}
 
-	etcd := etcd.Client{}
-	etcd.New()
+	rules, sets := s.rulesFor(req.Spec.Namespace)
+	r := &reviewer{
+		rules:    rules,
+		strategy: viper.GetString("server.conflict_strategy"),
+		now:      time.Now(),
+		fallback: defaultDecision(s.defaults, req.Spec.Namespace),
 
-	rules, err := etcd.GetAll(0)
-	if err != nil {
-		log.Panic(err)
-	}
-	defer etcd.Client.Close()
-
-	for _, rule := range rules.Rule {
-		if checkRule(rule, req) {
-			return &types.ImageReviewResponse{
-				TypeMeta: meta,
-				Status: types.ImageReviewStatus{
-					Allowed: rule.Allowed,
-					Reason:  rule.Reason,
-				},
-			}
+		namespaceLabels: reviewNamespaceLabels(rules, req.Spec.Namespace),
+	}
+
+	if trace {
+		r.trace = &types.ReviewTrace{Strategy: r.strategy, Sets: sets, Errors: s.Status().Errors}
+	}
+
+	if len(req.Spec.Containers) == 0 && !r.fallback.allowed {
+		reasons = append(reasons, r.fallback.reason)
+	}
+
+	ruleReq := s.breakGlass.without(req)
+	for _, container := range req.Spec.Containers {
+		if d := r.reviewContainer(container, ruleReq); !d.allowed {
+			denied = append(denied, container.Image)
+			reasons = append(reasons, d.reason)
+		}
+	}
+
+	// denies of Rego policies override decisions of rules
+	regoDenies := s.evalPolicies(req)
+	reasons = append(reasons, regoDenies...)
+	if r.trace != nil {
+		r.trace.RegoDenies = regoDenies
+	}
+
+	if len(reasons) != 0 {
+		ticket, err := s.breakGlass.ticket(req)
+		if err != nil {
+			reasons = append(reasons, err.Error())
+		} else if ticket != "" {
+			r.recordBreakGlass(ticket, req.Spec.Namespace, denied, reasons)
+			reasons = nil
 		}
 	}
 
@@ -42,43 +222,212 @@ func Review(req *types.ImageReview) *types.ImageReviewResponse {
 	return &types.ImageReviewResponse{
 		TypeMeta: meta,
 		Status: types.ImageReviewStatus{
-			Allowed: false,
-			Reason:  "Cannot match to any rule",
+			Allowed:          len(reasons) == 0,
+			Reason:           strings.Join(reasons, "; "),
+			AuditAnnotations: r.auditAnnotations,
+			Trace:            r.trace,
 		},
 	}
+}
 
+// decision contains result of the rule for a container
+type decision struct {
+	rule    *pb.Rule
+	allowed bool
+	reason  string
 }
 
-// checkRules checks if rule fulfill conditions.
-func checkRule(rule *pb.Rule, req *types.ImageReview) bool {
-	image, annotations, namespace := false, false, false
+// reviewContainer returns decision for the container based on rules which match to it.
+// In the case when several rules match then the decision is taken according to the strategy.
+// In the case when none of rules match then the fallback decision is taken.
+func (r *reviewer) reviewContainer(container types.ImageReviewContainerSpec, req *types.ImageReview) decision {
+	var matched []decision
 
-	for _, reqContainer := range req.Spec.Containers {
-		for _, container := range rule.Containers {
-			if matched, _ := regexp.MatchString(container.Image, reqContainer.Image); matched {
-				image = true
-				break
-			}
+	ref, err := ParseReference(container.Image)
+	if err != nil {
+		log.Println(err)
+	}
+
+	ct := r.traceContainer(container.Image)
+	for _, cr := range r.rules {
+		rule := cr.rule
+		if !cr.validity.isActive(r.now) {
+			ct.addRule(rule, nil, nil)
+			continue
+		}
+
+		m := checkRule(cr, container, ref, req, r.namespaceLabels)
+		if !m.matched() {
+			ct.addRule(rule, m, nil)
+			continue
+		}
+
+		d := ruleDecision(cr, m.container, container.Image, ref, req.Spec.Namespace)
+		ct.addRule(rule, m, &d)
+		if !isEnforced(rule) {
+			r.record(d, container.Image)
+			continue
 		}
+		matched = append(matched, d)
 	}
 
-	for reqKey, reqValue := range req.Spec.Annotations {
-		for key, value := range rule.Annotations {
-			if matched, _ := regexp.MatchString(key+"="+value, reqKey+"="+reqValue); matched {
-				annotations = true
-				break
+	result := r.resolve(matched, container.Image)
+	ct.setDecision(result)
+
+	return result
+}
+
+// resolve returns the decision according to the strategy,
+// or the fallback decision in the case when none of rules matched
+func (r *reviewer) resolve(matched []decision, image string) decision {
+	if len(matched) == 0 {
+		return decision{
+			allowed: r.fallback.allowed,
+			reason:  fmt.Sprintf("%s: %s", image, r.fallback.reason),
+		}
+	}
+
+	switch r.strategy {
+	case DenyOverrides:
+		for _, d := range matched {
+			if !d.allowed {
+				return d
+			}
+		}
+	case AllowOverrides:
+		for _, d := range matched {
+			if d.allowed {
+				return d
 			}
+		}
+	}
+
+	return matched[0]
+}
 
+// ruleDecision returns decision of the rule which matches to the image.
+// The image is denied in the case when it does not fulfill requirements of the rule.
+func ruleDecision(cr *compiledRule, container *pb.Rule_Containers, image string, ref *Reference, namespace string) decision {
+	rule := cr.rule
+	if violation := checkRequirements(rule, container, ref); violation != "" {
+		return decision{
+			rule:    rule,
+			allowed: false,
+			reason:  fmt.Sprintf("%s: denied by rule \"%s\": %s", image, rule.Name, violation),
 		}
 	}
-	// in the case when in request and in rule is lack of annotations then return true
-	if len(req.Spec.Annotations) == 0 && len(rule.Annotations) == 0 {
-		annotations = true
+
+	return decision{
+		rule:    rule,
+		allowed: rule.Allowed,
+		reason:  denyReason(image, ref, namespace, cr),
 	}
+}
+
+// sortByPriority sorts rules from the highest priority.
+// Rules with the same priority keep their order.
+func sortByPriority(rules []*pb.Rule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority > rules[j].Priority
+	})
+}
 
-	if matched, _ := regexp.MatchString(rule.Namespace, req.Spec.Namespace); matched {
-		namespace = true
+// sortCompiledByPriority sorts compiled rules like sortByPriority
+func sortCompiledByPriority(rules []*compiledRule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].rule.Priority > rules[j].rule.Priority
+	})
+}
+
+// denyReason returns description which image and by which rule was denied.
+// The reason of rule is rendered as a template.
+func denyReason(image string, ref *Reference, namespace string, cr *compiledRule) string {
+	if cr.rule.Reason == "" {
+		return fmt.Sprintf("%s: denied by rule \"%s\"", image, cr.rule.Name)
+	}
+
+	return fmt.Sprintf("%s: denied by rule \"%s\": %s", image, cr.rule.Name, renderReason(cr, image, ref, namespace))
+}
+
+// ruleMatch contains results of conditions of the rule for a container
+type ruleMatch struct {
+	// container is the container of the rule which matched to the image
+	container   *pb.Rule_Containers
+	excluded    bool
+	annotations bool
+	namespace   bool
+	condition   bool
+}
+
+// matched checks if all of conditions of the rule are fulfilled
+func (m *ruleMatch) matched() bool {
+	return m.container != nil && !m.excluded && m.annotations && m.namespace && m.condition
+}
+
+// checkRule checks if rule fulfill conditions for the given container.
+// The ref is nil in the case when the image of container cannot be parsed.
+// Both namespace pattern and namespace selector have to match to the namespace.
+func checkRule(cr *compiledRule, reqContainer types.ImageReviewContainerSpec, ref *Reference, req *types.ImageReview, nsLabels labels.Set) *ruleMatch {
+	m := &ruleMatch{}
+
+	for _, cc := range cr.containers {
+		if checkContainer(cc, reqContainer, ref) {
+			m.container = cc.container
+			break
+		}
+	}
+
+	for _, cc := range cr.excludeImages {
+		if checkContainer(cc, reqContainer, ref) {
+			m.excluded = true
+			break
+		}
+	}
+
+	m.annotations = checkAnnotations(cr, req.Spec.Annotations)
+	m.namespace = (cr.namespace == nil || cr.namespace.match(req.Spec.Namespace)) && checkNamespaceSelector(cr, nsLabels)
+
+	for _, excluded := range cr.excludeNamespaces {
+		if excluded.match(req.Spec.Namespace) {
+			m.namespace = false
+			break
+		}
+	}
+
+	m.condition = checkCondition(cr, reqContainer, ref, req)
+
+	return m
+}
+
+// checkContainer checks if every given part of image in the rule matches to the container
+// using match type of the container.
+func checkContainer(cc *compiledContainer, reqContainer types.ImageReviewContainerSpec, ref *Reference) bool {
+	if cc.image != nil && !cc.image.match(reqContainer.Image) {
+		return false
+	}
+
+	if cc.registry == nil && cc.repository == nil && cc.tag == nil && cc.digest == nil {
+		return checkSemver(cc, ref)
+	}
+
+	if ref == nil {
+		return false
+	}
+
+	parts := []struct {
+		matcher *matcher
+		value   string
+	}{
+		{cc.registry, ref.Registry},
+		{cc.repository, ref.Repository},
+		{cc.tag, ref.Tag},
+		{cc.digest, ref.Digest},
+	}
+	for _, part := range parts {
+		if part.matcher != nil && !part.matcher.match(part.value) {
+			return false
+		}
 	}
 
-	return (image && annotations && namespace)
+	return checkSemver(cc, ref)
 }
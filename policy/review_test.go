@@ -0,0 +1,76 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/types"
+)
+
+// pod returns the request of a pod in the namespace with containers of the images
+func pod(namespace string, images ...string) *types.ImageReview {
+	req := &types.ImageReview{Spec: types.ImageReviewSpec{Namespace: namespace}}
+	for _, image := range images {
+		req.Spec.Containers = append(req.Spec.Containers, types.ImageReviewContainerSpec{Image: image})
+	}
+
+	return req
+}
+
+func TestReviewEveryContainer(t *testing.T) {
+	viper.Set("server.default_allowed", true)
+	defer viper.Set("server.default_allowed", nil)
+
+	s := NewRuleSet(&Source{Rules: []*pb.Rule{
+		{Name: "no-latest", Namespace: ".", Containers: []*pb.Rule_Containers{{Image: ":latest$"}}},
+	}})
+
+	resp := s.Review(pod("default", "nginx:1.19", "busybox:latest", "gcr.io/proj/app:v1"))
+	if resp.Status.Allowed {
+		t.Fatal("pod with one denied container: got allowed")
+	}
+
+	// only the denied container is reported
+	if reason := resp.Status.Reason; !strings.Contains(reason, "busybox:latest") || strings.Contains(reason, "nginx") || strings.Contains(reason, "gcr.io") {
+		t.Errorf("got reason %q", reason)
+	}
+
+	if resp := s.Review(pod("default", "nginx:1.19", "gcr.io/proj/app:v1")); !resp.Status.Allowed {
+		t.Errorf("pod without denied containers: got denied: %s", resp.Status.Reason)
+	}
+}
+
+func TestReviewConflictStrategy(t *testing.T) {
+	defer viper.Set("server.conflict_strategy", nil)
+
+	s := NewRuleSet(&Source{Rules: []*pb.Rule{
+		{Name: "trusted", Namespace: ".", Allowed: true, Priority: 10, Containers: []*pb.Rule_Containers{{Registry: "^gcr\\.io$"}}},
+		{Name: "no-debug", Namespace: ".", Priority: 5, Containers: []*pb.Rule_Containers{{Tag: "debug"}}},
+		{Name: "banned", Namespace: ".", Priority: 20, Containers: []*pb.Rule_Containers{{Repository: "^proj/legacy$"}}},
+	}})
+
+	tests := []struct {
+		strategy string
+		image    string
+		allowed  bool
+	}{
+		// trusted has higher priority than no-debug
+		{FirstMatch, "gcr.io/proj/app:debug", true},
+		{DenyOverrides, "gcr.io/proj/app:debug", false},
+		{AllowOverrides, "gcr.io/proj/app:debug", true},
+
+		// banned has higher priority than trusted
+		{FirstMatch, "gcr.io/proj/legacy:v1", false},
+		{DenyOverrides, "gcr.io/proj/legacy:v1", false},
+		{AllowOverrides, "gcr.io/proj/legacy:v1", true},
+	}
+
+	for _, test := range tests {
+		viper.Set("server.conflict_strategy", test.strategy)
+		if resp := s.Review(pod("default", test.image)); resp.Status.Allowed != test.allowed {
+			t.Errorf("%s %s: got allowed %t, want %t", test.strategy, test.image, resp.Status.Allowed, test.allowed)
+		}
+	}
+}
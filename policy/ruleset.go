@@ -0,0 +1,345 @@
+package policy
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"text/template"
+
+	"github.com/Masterminds/semver"
+	"github.com/google/cel-go/cel"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/types"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// RuleSet is an immutable snapshot of compiled rules, defaults and Rego policies.
+// Patterns are compiled once when the snapshot is created, thus the review
+// does not compile anything.
+type RuleSet struct {
+	// rules are sorted from the highest priority
+	rules    []*compiledRule
+	defaults []*compiledDefault
+
+	// byName contains compiled rules by name
+	byName map[string]*compiledRule
+
+	// sets contains policy sets by name, bindings are in order of evaluation
+	sets     map[string]*pb.PolicySet
+	bindings []*compiledBinding
+
+	// global contains rules which are not in any set, sorted by priority
+	global []*compiledRule
+
+	// rego is the query of Rego policies prepared for evaluation,
+	// it is nil in the case when there are no Rego policies
+	rego    *rego.PreparedEvalQuery
+	regoErr error
+
+	// breakGlass contains compiled settings of break-glass
+	breakGlass *breakGlass
+
+	// errors contains errors of rules, defaults, sets and break-glass settings which cannot be compiled
+	errors []error
+}
+
+// compiledRule contains compiled patterns, condition and reason of the rule
+type compiledRule struct {
+	rule *pb.Rule
+
+	containers    []*compiledContainer
+	excludeImages []*compiledContainer
+
+	validity *validity
+
+	// namespace is nil in the case when the rule has no namespace
+	namespace         *matcher
+	excludeNamespaces []*matcher
+
+	// namespaceSelector is nil in the case when the rule has no namespace selector
+	namespaceSelector labels.Selector
+
+	annotations          []annotationMatcher
+	forbiddenAnnotations []annotationMatcher
+
+	// condition is nil in the case when the rule has no condition
+	condition cel.Program
+
+	// reason is nil in the case when the rule has no reason
+	reason *template.Template
+}
+
+// compiledContainer contains compiled patterns of the container of rule.
+// Patterns which are not given in the rule are nil.
+type compiledContainer struct {
+	container *pb.Rule_Containers
+
+	image      *matcher
+	registry   *matcher
+	repository *matcher
+	tag        *matcher
+	digest     *matcher
+
+	semver *semver.Constraints
+}
+
+// annotationMatcher contains compiled key and value of annotation,
+// the value is nil in the case when only presence of key is checked
+type annotationMatcher struct {
+	key   *matcher
+	value *matcher
+}
+
+// compiledDefault contains compiled namespace pattern of the default decision
+type compiledDefault struct {
+	data      *pb.Default
+	namespace *matcher
+}
+
+// Source contains objects from which the rule set is created
+type Source struct {
+	Rules    []*pb.Rule
+	Defaults []*pb.Default
+	Policies []*pb.Policy
+	Sets     []*pb.PolicySet
+	Bindings []*pb.Binding
+
+	// Errors contains errors of objects which cannot be read from the store
+	Errors []error
+}
+
+// NewRuleSet compiles rules, defaults, policy sets, bindings and Rego policies into a snapshot.
+// Rules, defaults and bindings which cannot be compiled are left out of the snapshot
+// and reported by Errors.
+func NewRuleSet(src *Source) *RuleSet {
+	s := &RuleSet{byName: make(map[string]*compiledRule)}
+	s.errors = append(s.errors, src.Errors...)
+
+	sorted := append([]*pb.Rule{}, src.Rules...)
+	sortByPriority(sorted)
+
+	for _, rule := range sorted {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			s.errors = append(s.errors, err)
+			continue
+		}
+		s.rules = append(s.rules, compiled)
+		s.byName[rule.Name] = compiled
+	}
+
+	for _, data := range src.Defaults {
+		compiled, err := compileDefault(data)
+		if err != nil {
+			s.errors = append(s.errors, err)
+			continue
+		}
+		s.defaults = append(s.defaults, compiled)
+	}
+
+	s.compileSets(src.Sets, src.Bindings)
+
+	s.rego, s.regoErr = compilePolicies(src.Policies)
+
+	var errors []error
+	s.breakGlass, errors = compileBreakGlass()
+	s.errors = append(s.errors, errors...)
+
+	for _, err := range s.errors {
+		log.Printf("Error: %s, it is left out of review\n", err)
+	}
+
+	return s
+}
+
+// Errors returns errors of rules and defaults which cannot be compiled
+// and of Rego policies
+func (s *RuleSet) Errors() []error {
+	if s.regoErr != nil {
+		return append(append([]error{}, s.errors...), s.regoErr)
+	}
+
+	return s.errors
+}
+
+// Len returns number of compiled rules
+func (s *RuleSet) Len() int {
+	return len(s.rules)
+}
+
+// compileRule checks the rule and compiles its patterns, condition and reason.
+// It returns the exact error of the first part which is not correct.
+func compileRule(rule *pb.Rule) (*compiledRule, error) {
+	var err error
+
+	if err := checkSettings(rule); err != nil {
+		return nil, fmt.Errorf("Rule %s: %s", rule.Name, err)
+	}
+
+	cr := &compiledRule{rule: rule}
+
+	cr.validity, err = compileValidity(rule)
+	if err != nil {
+		return nil, fmt.Errorf("Rule %s: %s", rule.Name, err)
+	}
+
+	for _, container := range rule.Containers {
+		cc, err := compileContainer(container)
+		if err != nil {
+			return nil, fmt.Errorf("Rule %s: image: %s", rule.Name, err)
+		}
+		cr.containers = append(cr.containers, cc)
+	}
+
+	for _, container := range rule.ExcludeImages {
+		cc, err := compileContainer(container)
+		if err != nil {
+			return nil, fmt.Errorf("Rule %s: excluded image: %s", rule.Name, err)
+		}
+		cr.excludeImages = append(cr.excludeImages, cc)
+	}
+
+	// the rule without namespace matches to all namespaces, e.g. rules of policy sets
+	if rule.Namespace != "" {
+		cr.namespace, err = compileMatcher(rule.NamespaceMatchType, rule.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("Rule %s: namespace: %s", rule.Name, err)
+		}
+	}
+
+	cr.namespaceSelector, err = compileNamespaceSelector(rule.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("Rule %s: %s", rule.Name, err)
+	}
+
+	for _, pattern := range rule.ExcludeNamespaces {
+		m, err := compileMatcher(rule.NamespaceMatchType, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("Rule %s: excluded namespace: %s", rule.Name, err)
+		}
+		cr.excludeNamespaces = append(cr.excludeNamespaces, m)
+	}
+
+	cr.annotations, err = compileAnnotations(rule.AnnotationsMatchType, rule.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("Rule %s: annotations: %s", rule.Name, err)
+	}
+
+	cr.forbiddenAnnotations, err = compileAnnotations(rule.AnnotationsMatchType, rule.ForbiddenAnnotations)
+	if err != nil {
+		return nil, fmt.Errorf("Rule %s: forbidden annotations: %s", rule.Name, err)
+	}
+
+	if rule.Condition != "" {
+		cr.condition, err = compileCondition(rule.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("Rule %s: %s", rule.Name, err)
+		}
+	}
+
+	if rule.Reason != "" {
+		cr.reason, err = compileReason(rule)
+		if err != nil {
+			return nil, fmt.Errorf("Rule %s: %s", rule.Name, err)
+		}
+	}
+
+	return cr, nil
+}
+
+// checkSettings checks settings of the rule which are not patterns
+func checkSettings(rule *pb.Rule) error {
+	if err := ValidateMode(rule.Mode); err != nil {
+		return err
+	}
+
+	if err := ValidateAnnotationsMatch(rule.AnnotationsMatch); err != nil {
+		return err
+	}
+
+	return ValidateMatchType(rule.AnnotationsMatchType)
+}
+
+// compileContainer compiles patterns of the given parts of image and semver constraint
+func compileContainer(container *pb.Rule_Containers) (*compiledContainer, error) {
+	cc := &compiledContainer{container: container}
+
+	if err := ValidateMatchType(container.MatchType); err != nil {
+		return nil, err
+	}
+
+	parts := []struct {
+		pattern string
+		matcher **matcher
+	}{
+		{container.Image, &cc.image},
+		{container.Registry, &cc.registry},
+		{container.Repository, &cc.repository},
+		{container.Tag, &cc.tag},
+		{container.Digest, &cc.digest},
+	}
+	for _, part := range parts {
+		if part.pattern == "" {
+			continue
+		}
+
+		m, err := compileMatcher(container.MatchType, part.pattern)
+		if err != nil {
+			return nil, err
+		}
+		*part.matcher = m
+	}
+
+	constraint, err := compileSemver(container)
+	if err != nil {
+		return nil, err
+	}
+	cc.semver = constraint
+
+	return cc, nil
+}
+
+// compileAnnotations compiles keys and values of annotations, the empty value is not compiled
+func compileAnnotations(matchType string, annotations map[string]string) ([]annotationMatcher, error) {
+	var result []annotationMatcher
+
+	// keys are sorted, so that the error is always about the same annotation
+	var keys []string
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var am annotationMatcher
+		var err error
+		value := annotations[key]
+
+		am.key, err = compileMatcher(matchType, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if value != "" {
+			am.value, err = compileMatcher(matchType, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result = append(result, am)
+	}
+
+	return result, nil
+}
+
+// Status reports number of rules and errors of the snapshot
+func (s *RuleSet) Status() *types.RuleSetStatus {
+	status := &types.RuleSetStatus{Rules: s.Len()}
+	for _, err := range s.Errors() {
+		status.Errors = append(status.Errors, err.Error())
+	}
+
+	return status
+}
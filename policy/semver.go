@@ -0,0 +1,84 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver"
+	"github.com/tczekajlo/kir/pb"
+)
+
+const (
+	// InvalidSemverNoMatch treats a tag which is not valid semantic version as not matched (default)
+	InvalidSemverNoMatch = "no-match"
+
+	// InvalidSemverDeny denies an image with a tag which is not valid semantic version
+	InvalidSemverDeny = "deny"
+
+	// InvalidSemverRegex matches an image with a tag which is not valid semantic version
+	// only by the rest of patterns
+	InvalidSemverRegex = "regex"
+)
+
+// compileSemver checks handling of invalid versions and compiles semver constraint.
+// It returns nil constraint in the case when the container has no constraint.
+func compileSemver(container *pb.Rule_Containers) (*semver.Constraints, error) {
+	switch container.InvalidSemver {
+	case "", InvalidSemverNoMatch, InvalidSemverDeny, InvalidSemverRegex:
+	default:
+		return nil, fmt.Errorf("Handling of invalid semver %s is not supported (%s, %s, %s)", container.InvalidSemver, InvalidSemverNoMatch, InvalidSemverDeny, InvalidSemverRegex)
+	}
+
+	if container.Semver == "" {
+		return nil, nil
+	}
+
+	constraint, err := semver.NewConstraint(container.Semver)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid semver constraint %s: %s", container.Semver, err)
+	}
+
+	return constraint, nil
+}
+
+// checkSemver checks if the tag of image fulfills semver constraint of the container.
+// The tag which is not valid semantic version is handled according to InvalidSemver.
+func checkSemver(cc *compiledContainer, ref *Reference) bool {
+	if cc.semver == nil {
+		return true
+	}
+
+	version, err := parseTag(ref)
+	if err != nil {
+		// the deny is reported as unfulfilled requirement of the rule
+		return cc.container.InvalidSemver == InvalidSemverDeny || cc.container.InvalidSemver == InvalidSemverRegex
+	}
+
+	return cc.semver.Check(version)
+}
+
+// checkSemverRequirement returns description of violation in the case when the tag of image
+// is not valid semantic version and the container denies such images.
+func checkSemverRequirement(container *pb.Rule_Containers, ref *Reference) string {
+	if container == nil || container.Semver == "" || container.InvalidSemver != InvalidSemverDeny {
+		return ""
+	}
+
+	if _, err := parseTag(ref); err != nil {
+		return err.Error()
+	}
+
+	return ""
+}
+
+func parseTag(ref *Reference) (*semver.Version, error) {
+	if ref == nil || ref.Tag == "" {
+		return nil, fmt.Errorf("image has no tag which is semantic version")
+	}
+
+	version, err := semver.NewVersion(ref.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("tag %s is not valid semantic version", ref.Tag)
+	}
+
+	return version, nil
+}
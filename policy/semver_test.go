@@ -0,0 +1,92 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/types"
+)
+
+// checkImage checks the image against nginx container with >= 1.19, < 2.0 constraint
+func checkImage(t *testing.T, invalidSemver, image string) (bool, string) {
+	t.Helper()
+
+	container := &pb.Rule_Containers{Repository: "^library/nginx$", Semver: ">= 1.19, < 2.0", InvalidSemver: invalidSemver}
+	cc, err := compileContainer(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, err := ParseReference(image)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return checkContainer(cc, types.ImageReviewContainerSpec{Image: image}, ref), checkSemverRequirement(container, ref)
+}
+
+func TestSemverConstraint(t *testing.T) {
+	matched := map[string]bool{
+		"nginx:1.18":        false,
+		"nginx:1.19.2":      true,
+		"nginx:v1.20":       true,
+		"nginx:2.0":         false,
+		"nginx:1.21-alpine": false,
+		"nginx":             false,
+		"nginx:alpine":      false,
+		"httpd:1.19.2":      false,
+	}
+
+	for image, want := range matched {
+		if got, _ := checkImage(t, "", image); got != want {
+			t.Errorf("%s: got matched %t, want %t", image, got, want)
+		}
+	}
+}
+
+func TestSemverInvalid(t *testing.T) {
+	t.Run(InvalidSemverNoMatch, func(t *testing.T) {
+		if matched, requirement := checkImage(t, InvalidSemverNoMatch, "nginx:alpine"); matched || requirement != "" {
+			t.Errorf("got matched %t with requirement %q, want not matched", matched, requirement)
+		}
+	})
+
+	t.Run(InvalidSemverDeny, func(t *testing.T) {
+		matched, requirement := checkImage(t, InvalidSemverDeny, "nginx")
+		if !matched || requirement != "image has no tag which is semantic version" {
+			t.Errorf("nginx: got matched %t with requirement %q", matched, requirement)
+		}
+
+		matched, requirement = checkImage(t, InvalidSemverDeny, "nginx:alpine")
+		if !matched || requirement != "tag alpine is not valid semantic version" {
+			t.Errorf("nginx:alpine: got matched %t with requirement %q", matched, requirement)
+		}
+
+		// valid version is not a violation
+		if _, requirement = checkImage(t, InvalidSemverDeny, "nginx:1.19.2"); requirement != "" {
+			t.Errorf("nginx:1.19.2: got requirement %q", requirement)
+		}
+	})
+
+	t.Run(InvalidSemverRegex, func(t *testing.T) {
+		if matched, _ := checkImage(t, InvalidSemverRegex, "nginx:alpine"); !matched {
+			t.Error("nginx:alpine: expected match by repository")
+		}
+		if matched, _ := checkImage(t, InvalidSemverRegex, "httpd:alpine"); matched {
+			t.Error("httpd:alpine: expected no match")
+		}
+		if matched, _ := checkImage(t, InvalidSemverRegex, "nginx:1.18"); matched {
+			t.Error("nginx:1.18: expected no match by constraint")
+		}
+	})
+}
+
+func TestSemverErrors(t *testing.T) {
+	if _, err := compileContainer(&pb.Rule_Containers{Semver: ">= one"}); err == nil {
+		t.Error("invalid constraint: expected error")
+	}
+
+	if _, err := compileContainer(&pb.Rule_Containers{Semver: ">= 1.0", InvalidSemver: "allow"}); err == nil {
+		t.Error("invalid handling of invalid semver: expected error")
+	}
+}
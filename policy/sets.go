@@ -0,0 +1,142 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/tczekajlo/kir/pb"
+)
+
+// compiledBinding contains compiled namespace patterns of the binding
+type compiledBinding struct {
+	data       *pb.Binding
+	namespaces []*matcher
+}
+
+// ValidateSet checks if the policy set is correct
+func ValidateSet(set *pb.PolicySet) error {
+	if len(set.Rules) == 0 {
+		return fmt.Errorf("Set %s: list of rules is empty", set.Name)
+	}
+
+	return nil
+}
+
+// ValidateBinding checks if the binding is correct and its namespace patterns compile
+func ValidateBinding(binding *pb.Binding) error {
+	_, err := compileBinding(binding)
+	return err
+}
+
+func compileBinding(binding *pb.Binding) (*compiledBinding, error) {
+	if len(binding.Namespaces) == 0 {
+		return nil, fmt.Errorf("Binding %s: list of namespaces is empty", binding.Name)
+	}
+
+	if len(binding.Sets) == 0 {
+		return nil, fmt.Errorf("Binding %s: list of sets is empty", binding.Name)
+	}
+
+	cb := &compiledBinding{data: binding}
+	for _, pattern := range binding.Namespaces {
+		m, err := compileMatcher(binding.NamespaceMatchType, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("Binding %s: %s", binding.Name, err)
+		}
+		cb.namespaces = append(cb.namespaces, m)
+	}
+
+	return cb, nil
+}
+
+// matches checks if any of namespace patterns of the binding matches to the namespace
+func (cb *compiledBinding) matches(namespace string) bool {
+	for _, m := range cb.namespaces {
+		if m.match(namespace) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compileSets compiles bindings and checks that sets and rules they refer to exist
+func (s *RuleSet) compileSets(sets []*pb.PolicySet, bindings []*pb.Binding) {
+	inSet := make(map[string]bool)
+
+	s.sets = make(map[string]*pb.PolicySet)
+	for _, set := range sets {
+		s.sets[set.Name] = set
+
+		for _, name := range set.Rules {
+			inSet[name] = true
+			if _, ok := s.byName[name]; !ok {
+				s.errors = append(s.errors, fmt.Errorf("Set %s: rule %s does not exist or is broken", set.Name, name))
+			}
+		}
+	}
+
+	for _, cr := range s.rules {
+		if !inSet[cr.rule.Name] {
+			s.global = append(s.global, cr)
+		}
+	}
+
+	for _, binding := range bindings {
+		cb, err := compileBinding(binding)
+		if err != nil {
+			s.errors = append(s.errors, err)
+			continue
+		}
+
+		for _, name := range binding.Sets {
+			if _, ok := s.sets[name]; !ok {
+				s.errors = append(s.errors, fmt.Errorf("Binding %s: set %s does not exist", binding.Name, name))
+			}
+		}
+		s.bindings = append(s.bindings, cb)
+	}
+}
+
+// rulesFor returns rules which have to be evaluated for the namespace and names of bound sets.
+// In the case when there are no bindings all rules are evaluated by priority. Otherwise sets
+// bound to the namespace are evaluated in binding order, i.e. order of bindings and sets
+// in the binding, and rules of a set by priority, followed by rules which are not in any set.
+// Rules of sets which are not bound to the namespace are not evaluated.
+func (s *RuleSet) rulesFor(namespace string) ([]*compiledRule, []string) {
+	var rules []*compiledRule
+	var sets []string
+
+	if len(s.bindings) == 0 {
+		return s.rules, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, cb := range s.bindings {
+		if !cb.matches(namespace) {
+			continue
+		}
+
+		for _, name := range cb.data.Sets {
+			set, ok := s.sets[name]
+			if !ok {
+				continue
+			}
+			sets = append(sets, name)
+
+			var setRules []*compiledRule
+			for _, ruleName := range set.Rules {
+				cr, ok := s.byName[ruleName]
+				if !ok || seen[ruleName] {
+					continue
+				}
+				seen[ruleName] = true
+				setRules = append(setRules, cr)
+			}
+			sortCompiledByPriority(setRules)
+			rules = append(rules, setRules...)
+		}
+	}
+	rules = append(rules, s.global...)
+
+	return rules, sets
+}
@@ -0,0 +1,46 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tczekajlo/kir/pb"
+)
+
+func TestRulesForBindingOrder(t *testing.T) {
+	s := NewRuleSet(&Source{
+		Rules: []*pb.Rule{
+			{Name: "base-a", Namespace: "."},
+			{Name: "base-b", Namespace: ".", Priority: 10},
+			{Name: "prod", Namespace: ".", Priority: 100},
+			{Name: "global", Namespace: ".", Priority: 1000},
+		},
+		Sets: []*pb.PolicySet{
+			{Name: "baseline", Rules: []string{"base-a", "base-b"}},
+			{Name: "production", Rules: []string{"prod"}},
+		},
+		Bindings: []*pb.Binding{
+			{Name: "a-baseline", Namespaces: []string{"."}, Sets: []string{"baseline"}},
+			{Name: "b-production", Namespaces: []string{"^prod-"}, Sets: []string{"production"}},
+		},
+	})
+
+	names := func(namespace string) string {
+		rules, _ := s.rulesFor(namespace)
+
+		var result []string
+		for _, cr := range rules {
+			result = append(result, cr.rule.Name)
+		}
+		return strings.Join(result, ",")
+	}
+
+	// priority orders rules within the set only, the global rule is the last one
+	if got := names("prod-web"); got != "base-b,base-a,prod,global" {
+		t.Errorf("prod-web: got %s", got)
+	}
+
+	if got := names("dev"); got != "base-b,base-a,global" {
+		t.Errorf("dev: got %s", got)
+	}
+}
@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"github.com/tczekajlo/kir/pb"
+	"github.com/tczekajlo/kir/types"
+)
+
+// DecidedByDefault means that the decision was taken by the default decision
+const DecidedByDefault = "default"
+
+// containerTrace collects results of rules for a container.
+// All of methods do nothing if the trace was not requested.
+type containerTrace struct {
+	*types.ContainerTrace
+}
+
+// traceContainer starts trace of the container in the case when the trace was requested
+func (r *reviewer) traceContainer(image string) containerTrace {
+	if r.trace == nil {
+		return containerTrace{}
+	}
+
+	ct := &types.ContainerTrace{Image: image}
+	r.trace.Containers = append(r.trace.Containers, ct)
+
+	return containerTrace{ct}
+}
+
+// addRule records results of the rule. The match is nil in the case when the rule is not active,
+// the decision is nil in the case when the rule did not match.
+func (ct containerTrace) addRule(rule *pb.Rule, m *ruleMatch, d *decision) {
+	if ct.ContainerTrace == nil {
+		return
+	}
+
+	rt := types.RuleTrace{
+		Name:     rule.Name,
+		Priority: rule.Priority,
+		Mode:     rule.Mode,
+	}
+	if rt.Mode == "" {
+		rt.Mode = ModeEnforce
+	}
+
+	if m != nil {
+		rt.Active = true
+		rt.ImageMatched = m.container != nil
+		rt.ImageExcluded = m.excluded
+		rt.NamespaceMatched = m.namespace
+		rt.AnnotationsMatched = m.annotations
+		rt.ConditionMatched = m.condition
+		rt.Matched = m.matched()
+	}
+
+	if d != nil {
+		rt.Allowed = d.allowed
+		if !d.allowed {
+			rt.Reason = d.reason
+		}
+	}
+
+	ct.Rules = append(ct.Rules, rt)
+}
+
+// setDecision records the final decision for the container
+func (ct containerTrace) setDecision(d decision) {
+	if ct.ContainerTrace == nil {
+		return
+	}
+
+	ct.Allowed = d.allowed
+	ct.DecidedBy = DecidedByDefault
+	if d.rule != nil {
+		ct.DecidedBy = d.rule.Name
+	}
+
+	if !d.allowed {
+		ct.Reason = d.reason
+	}
+}
@@ -0,0 +1,13 @@
+package policy
+
+import (
+	"github.com/tczekajlo/kir/pb"
+)
+
+// Validate checks if the rule is correct before it is stored.
+// Every pattern, condition and reason of the rule is compiled,
+// and the exact error of the first invalid one is returned.
+func Validate(rule *pb.Rule) error {
+	_, err := compileRule(rule)
+	return err
+}
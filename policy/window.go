@@ -0,0 +1,209 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tczekajlo/kir/pb"
+)
+
+// days contains supported names of days used in windows of rules
+var days = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// validity contains the validity period and windows of the rule parsed once,
+// zero times mean that the period is not limited on that side
+type validity struct {
+	from  time.Time
+	until time.Time
+
+	windows []*compiledWindow
+}
+
+// compiledWindow contains the window with its location and times of day parsed once
+type compiledWindow struct {
+	window   *pb.Rule_Window
+	location *time.Location
+	start    time.Duration
+	end      time.Duration
+}
+
+// isActive checks if the rule is valid at the given time.
+// The rule is active between valid_from and valid_until, and within any of its windows.
+func (v *validity) isActive(now time.Time) bool {
+	if !v.from.IsZero() && now.Before(v.from) {
+		return false
+	}
+
+	if !v.until.IsZero() && !now.Before(v.until) {
+		return false
+	}
+
+	if len(v.windows) == 0 {
+		return true
+	}
+
+	for _, window := range v.windows {
+		if window.contains(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contains checks if the time is within the window. The window which ends
+// before it starts lasts past midnight, and its days are days on which it starts.
+func (w *compiledWindow) contains(now time.Time) bool {
+	now = now.In(w.location)
+	current := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+
+	if w.start < w.end {
+		return current >= w.start && current < w.end && hasDay(w.window, now.Weekday())
+	}
+
+	if current >= w.start {
+		return hasDay(w.window, now.Weekday())
+	}
+
+	return current < w.end && hasDay(w.window, now.AddDate(0, 0, -1).Weekday())
+}
+
+// hasDay checks if the window contains the day, the window without days contains every day
+func hasDay(window *pb.Rule_Window, day time.Weekday) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+
+	for _, d := range window.Days {
+		if strings.ToLower(d) == days[day] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseWindow(window *pb.Rule_Window) (*time.Location, time.Duration, time.Duration, error) {
+	location, err := time.LoadLocation(window.Timezone)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("Invalid timezone %s: %s", window.Timezone, err)
+	}
+
+	start, err := parseClock(window.Start)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	end, err := parseClock(window.End)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return location, start, end, nil
+}
+
+// parseClock parses time of day in the form HH:MM
+func parseClock(clock string) (time.Duration, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid time of day %s, use HH:MM format", clock)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// compileValidity checks and parses validity period and windows of the rule
+func compileValidity(rule *pb.Rule) (*validity, error) {
+	v := &validity{}
+
+	periods := []struct {
+		value string
+		time  *time.Time
+	}{
+		{rule.ValidFrom, &v.from},
+		{rule.ValidUntil, &v.until},
+	}
+	for _, period := range periods {
+		if period.value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, period.value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid time %s, use RFC3339 format, e.g. 2017-05-14T09:00:00Z", period.value)
+		}
+		*period.time = t
+	}
+
+	for _, window := range rule.Windows {
+		location, start, end, err := parseWindow(window)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, day := range window.Days {
+			if indexOfDay(day) == -1 {
+				return nil, fmt.Errorf("Invalid day %s, use one of %s", day, strings.Join(days, ", "))
+			}
+		}
+
+		v.windows = append(v.windows, &compiledWindow{window: window, location: location, start: start, end: end})
+	}
+
+	return v, nil
+}
+
+// ParseWindow parses window in the form "DAYS START-END [TIMEZONE]",
+// e.g. "mon-fri 09:00-17:00 Europe/Warsaw". Days are given as a list separated
+// by a comma or as a range, "*" means every day.
+func ParseWindow(value string) (*pb.Rule_Window, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, fmt.Errorf("Cannot parse window %s, use \"DAYS START-END [TIMEZONE]\" format", value)
+	}
+
+	window := &pb.Rule_Window{}
+	if fields[0] != "*" {
+		for _, item := range strings.Split(fields[0], ",") {
+			dayRange := strings.SplitN(item, "-", 2)
+			first, last := indexOfDay(dayRange[0]), indexOfDay(dayRange[len(dayRange)-1])
+			if first == -1 || last == -1 {
+				return nil, fmt.Errorf("Invalid days %s, use one of %s", item, strings.Join(days, ", "))
+			}
+
+			for i := first; ; i = (i + 1) % len(days) {
+				window.Days = append(window.Days, days[i])
+				if i == last {
+					break
+				}
+			}
+		}
+	}
+
+	clock := strings.SplitN(fields[1], "-", 2)
+	if len(clock) != 2 {
+		return nil, fmt.Errorf("Cannot parse window %s, use START-END format of time, e.g. 09:00-17:00", value)
+	}
+	window.Start, window.End = clock[0], clock[1]
+
+	if len(fields) == 3 {
+		window.Timezone = fields[2]
+	}
+
+	if _, _, _, err := parseWindow(window); err != nil {
+		return nil, err
+	}
+
+	return window, nil
+}
+
+func indexOfDay(day string) int {
+	for i, d := range days {
+		if strings.ToLower(day) == d {
+			return i
+		}
+	}
+
+	return -1
+}
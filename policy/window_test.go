@@ -0,0 +1,103 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tczekajlo/kir/pb"
+)
+
+// activeAt compiles validity of the rule with the given windows and checks it at the time in RFC 3339
+func activeAt(t *testing.T, rule *pb.Rule, windows []string, at string) bool {
+	t.Helper()
+
+	for _, value := range windows {
+		window, err := ParseWindow(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rule.Windows = append(rule.Windows, window)
+	}
+
+	v, err := compileValidity(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return v.isActive(now)
+}
+
+func TestWindows(t *testing.T) {
+	// office hours in Warsaw, UTC+2 in October
+	office := []string{"mon-fri 09:00-17:00 Europe/Warsaw"}
+	if !activeAt(t, &pb.Rule{}, office, "2026-10-14T10:00:00Z") {
+		t.Error("Wednesday 12:00 in Warsaw: expected active")
+	}
+	if activeAt(t, &pb.Rule{}, office, "2026-10-14T15:00:00Z") {
+		t.Error("Wednesday 17:00 in Warsaw: expected inactive")
+	}
+	if activeAt(t, &pb.Rule{}, office, "2026-10-17T10:00:00Z") {
+		t.Error("Saturday in Warsaw: expected inactive")
+	}
+
+	// the window past midnight belongs to the day on which it starts
+	weekend := []string{"fri-sun 22:00-06:00"}
+	for at, active := range map[string]bool{
+		"2026-10-16T23:00:00Z": true,  // Friday night
+		"2026-10-19T05:00:00Z": true,  // Monday morning after Sunday night
+		"2026-10-16T05:00:00Z": false, // Friday morning after Thursday night
+	} {
+		if got := activeAt(t, &pb.Rule{}, weekend, at); got != active {
+			t.Errorf("%v at %s: got active %t, want %t", weekend, at, got, active)
+		}
+	}
+
+	// the rule is active within any of its windows
+	lunch := []string{"mon 09:00-10:00", "* 12:00-13:00"}
+	if !activeAt(t, &pb.Rule{}, lunch, "2026-10-14T12:30:00Z") || activeAt(t, &pb.Rule{}, lunch, "2026-10-14T09:30:00Z") {
+		t.Errorf("%v: expected active on Wednesday only at lunch", lunch)
+	}
+}
+
+func TestValidityPeriod(t *testing.T) {
+	now := "2026-10-14T10:00:00Z"
+
+	if !activeAt(t, &pb.Rule{ValidFrom: "2026-10-01T00:00:00Z"}, nil, now) {
+		t.Error("valid_from in the past: expected active")
+	}
+	if activeAt(t, &pb.Rule{ValidFrom: "2026-11-01T00:00:00Z"}, nil, now) {
+		t.Error("valid_from in the future: expected inactive")
+	}
+	if activeAt(t, &pb.Rule{ValidUntil: "2026-10-01T00:00:00Z"}, nil, now) {
+		t.Error("expired rule: expected inactive")
+	}
+	if activeAt(t, &pb.Rule{ValidUntil: now}, nil, now) {
+		t.Error("valid_until is not included: expected inactive")
+	}
+	if activeAt(t, &pb.Rule{ValidUntil: "2026-10-01T00:00:00Z"}, []string{"* 09:00-17:00"}, now) {
+		t.Error("expired rule within window: expected inactive")
+	}
+}
+
+func TestWindowsErrors(t *testing.T) {
+	for _, value := range []string{"xyz 09:00-10:00", "mon 9-10", "mon 09:00", "mon 09:00-10:00 Mars/Olympus"} {
+		if _, err := ParseWindow(value); err == nil {
+			t.Errorf("%s: expected error", value)
+		}
+	}
+
+	for _, rule := range []*pb.Rule{
+		{Name: "from", ValidFrom: "tomorrow"},
+		{Name: "until", ValidUntil: "2026-10-14"},
+		{Name: "day", Windows: []*pb.Rule_Window{{Days: []string{"someday"}, Start: "09:00", End: "10:00"}}},
+	} {
+		if err := Validate(rule); err == nil {
+			t.Errorf("%s: expected error", rule.Name)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// ErrConflict means that objects are changed since their resource versions were read
+var ErrConflict = errors.New("Objects were changed in the meantime")
+
+// BatchStore is a store which makes several changes at once
+type BatchStore interface {
+	Store
+
+	// Apply makes the changes in the case when none of objects is changed since
+	// its resource version was read, otherwise it returns ErrConflict. Changes may be
+	// split into several transactions, it returns number of changes made anyway.
+	Apply(changes []*Change) (int, error)
+}
+
+// Change is a change of object made by Apply
+type Change struct {
+	Kind string
+	Name string
+
+	// Data is the object to store, nil deletes the object
+	Data proto.Message
+
+	// ResourceVersion is the current resource version of the object,
+	// 0 means that the object does not exist
+	ResourceVersion int64
+}
+
+// Apply makes the changes in one transaction in the case when the store supports it,
+// otherwise one by one. Resource version of every object is checked before its change,
+// thus it stops with ErrConflict at the first object changed in the meantime. It returns
+// number of changes made, also in the case when it stops part-way.
+func Apply(s Store, changes []*Change) (int, error) {
+	if bs, ok := s.(BatchStore); ok {
+		return bs.Apply(changes)
+	}
+
+	for i, change := range changes {
+		if change.Data == nil {
+			current, err := newObject(change.Kind)
+			if err != nil {
+				return i, err
+			}
+			if err := s.Get(change.Kind, change.Name, current); err != nil {
+				return i, err
+			}
+			if resourceVersion(current) != change.ResourceVersion {
+				return i, ErrConflict
+			}
+
+			if _, err := s.Delete(change.Kind, change.Name); err != nil {
+				return i, err
+			}
+			continue
+		}
+
+		data := proto.Clone(change.Data)
+		setResourceVersion(data, change.ResourceVersion)
+
+		stored, err := s.Add(change.Kind, change.Name, data, change.ResourceVersion != 0)
+		if err != nil {
+			return i, err
+		}
+		if !stored {
+			return i, ErrConflict
+		}
+	}
+
+	return len(changes), nil
+}
@@ -0,0 +1,287 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/etcd"
+	"github.com/tczekajlo/kir/pb"
+)
+
+// EtcdStore keeps objects in etcd under kind/name keys
+type EtcdStore struct {
+	Client *etcd.Client
+}
+
+// NewEtcd connects to etcd given by etcd settings
+func NewEtcd() (*EtcdStore, error) {
+	client := &etcd.Client{}
+	if err := client.Connect(); err != nil {
+		return nil, fmt.Errorf("Cannot connect to etcd: %s", err)
+	}
+
+	return &EtcdStore{Client: client}, nil
+}
+
+// Add stores the object in a transaction which checks existence of the key,
+// resource version of the object is mod revision of the key
+func (s *EtcdStore) Add(kind, name string, data proto.Message, override bool) (bool, error) {
+	if err := s.Client.Put(kind, name, withoutResourceVersion(data), override, resourceVersion(data)); err != nil {
+		return false, err
+	}
+
+	return s.Client.TxnResponse.Succeeded, nil
+}
+
+// AddWithTTL stores the object with a lease, thus etcd removes the object after ttl
+func (s *EtcdStore) AddWithTTL(kind, name string, data proto.Message, override bool, ttl time.Duration) (bool, error) {
+	if err := s.Client.PutWithTTL(kind, name, withoutResourceVersion(data), override, resourceVersion(data), ttl); err != nil {
+		return false, err
+	}
+
+	return s.Client.TxnResponse.Succeeded, nil
+}
+
+// Get decodes the object into data
+func (s *EtcdStore) Get(kind, name string, data proto.Message) error {
+	if err := s.Client.Fetch(kind, name, data); err != nil {
+		return err
+	}
+	setResourceVersion(data, s.Client.GetResponse.Kvs[0].ModRevision)
+
+	return nil
+}
+
+// GetAll returns objects of the kind sorted by name
+func (s *EtcdStore) GetAll(kind string, limit int64) (*List, error) {
+	values, err := s.Client.List(kind, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &List{Count: s.Client.GetResponse.Count}
+	for i, value := range values {
+		data, err := newObject(kind)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := proto.Unmarshal(value, data); err != nil {
+			return nil, fmt.Errorf("Failed to parse %s: %s", kind, err)
+		}
+		setResourceVersion(data, s.Client.GetResponse.Kvs[i].ModRevision)
+		result.Items = append(result.Items, data)
+	}
+
+	return result, nil
+}
+
+// Delete deletes the object
+func (s *EtcdStore) Delete(kind, name string) (int64, error) {
+	if err := s.Client.Remove(kind, name); err != nil {
+		return 0, err
+	}
+
+	return s.Client.DeleteResponse.Deleted, nil
+}
+
+// Close closes connection to etcd
+func (s *EtcdStore) Close() error {
+	return s.Client.Client.Close()
+}
+
+// Load returns all objects stored in etcd at the current revision. Objects which
+// cannot be decoded are reported by errors of the snapshot.
+func (s *EtcdStore) Load() (*Snapshot, error) {
+	resp, err := s.Client.ListKinds(Kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := NewSnapshot(resp.Header.Revision)
+	for i, kind := range Kinds {
+		for _, kv := range resp.Responses[i].GetResponseRange().Kvs {
+			_, name := splitKey(string(kv.Key))
+			data, _ := newObject(kind)
+
+			if err := proto.Unmarshal(kv.Value, data); err != nil {
+				snapshot.Errors[kind+"/"+name] = fmt.Errorf("Failed to parse %s %s: %s", kind, name, err)
+				continue
+			}
+			setResourceVersion(data, kv.ModRevision)
+			snapshot.Objects[kind][name] = data
+		}
+	}
+
+	return snapshot, nil
+}
+
+// History returns versions of the object kept in history keys of etcd
+func (s *EtcdStore) History(kind, name string) ([]*Version, error) {
+	kvs, err := s.Client.History(kind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Version
+	for _, kv := range kvs {
+		stored := &pb.Version{}
+		if err := proto.Unmarshal(kv.Value, stored); err != nil {
+			return nil, fmt.Errorf("Failed to parse version of %s %s: %s", kind, name, err)
+		}
+
+		version := &Version{
+			Revision:  kv.ModRevision,
+			Timestamp: time.Unix(0, stored.Timestamp),
+			Deleted:   stored.Deleted,
+		}
+		if !stored.Deleted {
+			data, err := newObject(kind)
+			if err != nil {
+				return nil, err
+			}
+			if err := proto.Unmarshal(stored.Data, data); err != nil {
+				return nil, fmt.Errorf("Failed to parse version of %s %s: %s", kind, name, err)
+			}
+			version.Data = data
+		}
+		result = append(result, version)
+	}
+
+	return result, nil
+}
+
+// Rollback stores the version of object in a transaction which checks that the object
+// is not changed since its mod revision was read. A deleted object is stored
+// only if it is still missing.
+func (s *EtcdStore) Rollback(kind, name string, revision int64) (bool, error) {
+	versions, err := s.History(kind, name)
+	if err != nil {
+		return false, err
+	}
+
+	version, err := findVersion(versions, kind, name, revision)
+	if err != nil {
+		return false, err
+	}
+
+	modRevision, err := s.Client.ModRevision(kind, name)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.Client.Put(kind, name, version.Data, modRevision != 0, modRevision); err != nil {
+		return false, err
+	}
+
+	return s.Client.TxnResponse.Succeeded, nil
+}
+
+// Watch sends changes of objects made after the revision. Objects which cannot
+// be decoded are sent with the error, thus the watch goes on.
+func (s *EtcdStore) Watch(ctx context.Context, revision int64) <-chan *WatchResponse {
+	result := make(chan *WatchResponse)
+
+	go func() {
+		defer close(result)
+
+		for wr := range s.Client.Watch(ctx, revision+1) {
+			resp := &WatchResponse{Revision: wr.Header.Revision}
+
+			switch {
+			case wr.CompactRevision != 0:
+				resp.Compacted = true
+			case wr.Err() != nil:
+				resp.Err = wr.Err()
+			}
+
+			for _, ev := range wr.Events {
+				kind, name := splitKey(string(ev.Kv.Key))
+				data, err := newObject(kind)
+				if err != nil {
+					continue
+				}
+
+				event := &Event{Kind: kind, Name: name}
+				if ev.Type == mvccpb.PUT {
+					if err := proto.Unmarshal(ev.Kv.Value, data); err != nil {
+						event.Err = fmt.Errorf("Failed to parse %s %s: %s", kind, name, err)
+					} else {
+						setResourceVersion(data, ev.Kv.ModRevision)
+						event.Data = data
+					}
+				}
+				resp.Events = append(resp.Events, event)
+			}
+
+			select {
+			case result <- resp:
+			case <-ctx.Done():
+				return
+			}
+
+			if resp.Compacted || resp.Err != nil {
+				return
+			}
+		}
+	}()
+
+	return result
+}
+
+// Revision returns current revision of etcd
+func (s *EtcdStore) Revision() (int64, error) {
+	return s.Client.Revision()
+}
+
+// Apply makes the changes in transactions which contain at most etcd.max_txn_ops
+// operations, every change takes up to three of them because of history and its trimming
+func (s *EtcdStore) Apply(changes []*Change) (int, error) {
+	size := viper.GetInt("etcd.max_txn_ops") / 3
+	if size < 1 {
+		size = 1
+	}
+
+	applied := 0
+	for len(changes) > 0 {
+		chunk := changes
+		if len(chunk) > size {
+			chunk = chunk[:size]
+		}
+		changes = changes[len(chunk):]
+
+		var batch []*etcd.Change
+		for _, change := range chunk {
+			item := &etcd.Change{Kind: change.Kind, Name: change.Name, ModRevision: change.ResourceVersion}
+			if change.Data != nil {
+				item.Data = withoutResourceVersion(change.Data)
+			}
+			batch = append(batch, item)
+		}
+
+		if err := s.Client.Commit(batch); err != nil {
+			return applied, err
+		}
+		if !s.Client.TxnResponse.Succeeded {
+			return applied, ErrConflict
+		}
+		applied += len(chunk)
+	}
+
+	return applied, nil
+}
+
+// splitKey returns kind and name of kind/name key
+func splitKey(key string) (string, string) {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return "", key
+	}
+
+	return parts[0], parts[1]
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// HistoryStore is a store which keeps every version of objects
+type HistoryStore interface {
+	Store
+
+	// History returns versions of the object from the oldest one
+	History(kind, name string) ([]*Version, error)
+
+	// Rollback stores the object as it was at the revision. It returns false
+	// in the case when the object is changed in the meantime.
+	Rollback(kind, name string, revision int64) (bool, error)
+}
+
+// Version is the object as it was after a change
+type Version struct {
+	// Revision is revision of the store at which the object was changed
+	Revision int64
+
+	// Timestamp is time of the change
+	Timestamp time.Time
+
+	// Deleted means that the object was deleted by the change
+	Deleted bool
+
+	// Data is the object, it is nil in the case when the object was deleted
+	Data proto.Message
+}
+
+// findVersion returns the version of object changed at the revision
+func findVersion(versions []*Version, kind, name string, revision int64) (*Version, error) {
+	for _, version := range versions {
+		if version.Revision != revision {
+			continue
+		}
+
+		if version.Deleted {
+			return nil, fmt.Errorf("Revision %d deletes %s %s, it cannot be restored", revision, kind, name)
+		}
+		return version, nil
+	}
+
+	return nil, fmt.Errorf("Cannot find revision %d of %s %s", revision, kind, name)
+}
@@ -0,0 +1,170 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// memory is the store shared by all users of memory backend in the process
+var memory = NewMemory()
+
+// MemoryStore keeps objects in memory of the process. Objects are kept encoded,
+// so that changes of returned objects do not change the store.
+type MemoryStore struct {
+	sync.RWMutex
+	objects map[string]map[string]*memoryObject
+
+	// revision is incremented by every change, resource version of object
+	// is revision of its last change
+	revision int64
+}
+
+type memoryObject struct {
+	value    []byte
+	revision int64
+}
+
+// NewMemory creates an empty memory store
+func NewMemory() *MemoryStore {
+	return &MemoryStore{objects: make(map[string]map[string]*memoryObject)}
+}
+
+// Add stores the object
+func (s *MemoryStore) Add(kind, name string, data proto.Message, override bool) (bool, error) {
+	out, err := proto.Marshal(withoutResourceVersion(data))
+	if err != nil {
+		return false, fmt.Errorf("Failed to encode %s: %s", kind, err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	object, exists := s.objects[kind][name]
+	if exists != override {
+		return false, nil
+	}
+	if version := resourceVersion(data); exists && version != 0 && version != object.revision {
+		return false, nil
+	}
+
+	if s.objects[kind] == nil {
+		s.objects[kind] = make(map[string]*memoryObject)
+	}
+	s.revision++
+	s.objects[kind][name] = &memoryObject{value: out, revision: s.revision}
+
+	return true, nil
+}
+
+// Get decodes the object into data
+func (s *MemoryStore) Get(kind, name string, data proto.Message) error {
+	s.RLock()
+	object, exists := s.objects[kind][name]
+	s.RUnlock()
+
+	if !exists {
+		return notFound(kind)
+	}
+
+	if err := proto.Unmarshal(object.value, data); err != nil {
+		return fmt.Errorf("Failed to parse %s: %s", kind, err)
+	}
+	setResourceVersion(data, object.revision)
+
+	return nil
+}
+
+// GetAll returns objects of the kind sorted by name
+func (s *MemoryStore) GetAll(kind string, limit int64) (*List, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	var names []string
+	for name := range s.objects[kind] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := &List{Count: int64(len(names))}
+	for _, name := range limitNames(names, limit) {
+		data, err := newObject(kind)
+		if err != nil {
+			return nil, err
+		}
+
+		object := s.objects[kind][name]
+		if err := proto.Unmarshal(object.value, data); err != nil {
+			return nil, fmt.Errorf("Failed to parse %s: %s", kind, err)
+		}
+		setResourceVersion(data, object.revision)
+		result.Items = append(result.Items, data)
+	}
+
+	return result, nil
+}
+
+// Delete deletes the object
+func (s *MemoryStore) Delete(kind, name string) (int64, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, exists := s.objects[kind][name]; !exists {
+		return 0, nil
+	}
+	delete(s.objects[kind], name)
+	s.revision++
+
+	return 1, nil
+}
+
+// Apply makes all changes at once
+func (s *MemoryStore) Apply(changes []*Change) (int, error) {
+	var values [][]byte
+
+	for _, change := range changes {
+		var out []byte
+		if change.Data != nil {
+			var err error
+			if out, err = proto.Marshal(withoutResourceVersion(change.Data)); err != nil {
+				return 0, fmt.Errorf("Failed to encode %s: %s", change.Kind, err)
+			}
+		}
+		values = append(values, out)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	for _, change := range changes {
+		var revision int64
+		if object, exists := s.objects[change.Kind][change.Name]; exists {
+			revision = object.revision
+		}
+		if revision != change.ResourceVersion {
+			return 0, ErrConflict
+		}
+	}
+
+	s.revision++
+	for i, change := range changes {
+		if change.Data == nil {
+			delete(s.objects[change.Kind], change.Name)
+			continue
+		}
+
+		if s.objects[change.Kind] == nil {
+			s.objects[change.Kind] = make(map[string]*memoryObject)
+		}
+		s.objects[change.Kind][change.Name] = &memoryObject{value: values[i], revision: s.revision}
+	}
+
+	return len(changes), nil
+}
+
+// Close does nothing, objects are kept as long as the process runs
+func (s *MemoryStore) Close() error {
+	return nil
+}
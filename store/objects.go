@@ -0,0 +1,116 @@
+package store
+
+import (
+	"github.com/tczekajlo/kir/pb"
+)
+
+// GetRule returns the rule by name
+func GetRule(s Store, name string) (*pb.Rule, error) {
+	data := &pb.Rule{}
+
+	return data, s.Get(Rule, name, data)
+}
+
+// GetRules returns rules sorted by name, limit 0 means all rules.
+// It returns number of all rules as well.
+func GetRules(s Store, limit int64) (*pb.RulesList, int64, error) {
+	list, err := s.GetAll(Rule, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := &pb.RulesList{}
+	for _, item := range list.Items {
+		result.Rule = append(result.Rule, item.(*pb.Rule))
+	}
+
+	return result, list.Count, nil
+}
+
+// GetDefault returns default decision for namespaces by name
+func GetDefault(s Store, name string) (*pb.Default, error) {
+	data := &pb.Default{}
+
+	return data, s.Get(Default, name, data)
+}
+
+// GetDefaults returns all default decisions for namespaces sorted by name
+func GetDefaults(s Store) (*pb.DefaultsList, error) {
+	list, err := s.GetAll(Default, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pb.DefaultsList{}
+	for _, item := range list.Items {
+		result.Default = append(result.Default, item.(*pb.Default))
+	}
+
+	return result, nil
+}
+
+// GetPolicy returns Rego policy by name
+func GetPolicy(s Store, name string) (*pb.Policy, error) {
+	data := &pb.Policy{}
+
+	return data, s.Get(Policy, name, data)
+}
+
+// GetPolicies returns all Rego policies sorted by name
+func GetPolicies(s Store) (*pb.PoliciesList, error) {
+	list, err := s.GetAll(Policy, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pb.PoliciesList{}
+	for _, item := range list.Items {
+		result.Policy = append(result.Policy, item.(*pb.Policy))
+	}
+
+	return result, nil
+}
+
+// GetSet returns policy set by name
+func GetSet(s Store, name string) (*pb.PolicySet, error) {
+	data := &pb.PolicySet{}
+
+	return data, s.Get(Set, name, data)
+}
+
+// GetSets returns all policy sets sorted by name
+func GetSets(s Store) (*pb.PolicySetsList, error) {
+	list, err := s.GetAll(Set, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pb.PolicySetsList{}
+	for _, item := range list.Items {
+		result.Set = append(result.Set, item.(*pb.PolicySet))
+	}
+
+	return result, nil
+}
+
+// GetBinding returns binding by name
+func GetBinding(s Store, name string) (*pb.Binding, error) {
+	data := &pb.Binding{}
+
+	return data, s.Get(Binding, name, data)
+}
+
+// GetBindings returns all bindings sorted by name
+func GetBindings(s Store) (*pb.BindingsList, error) {
+	list, err := s.GetAll(Binding, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pb.BindingsList{}
+	for _, item := range list.Items {
+		result.Binding = append(result.Binding, item.(*pb.Binding))
+	}
+
+	return result, nil
+}
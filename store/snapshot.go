@@ -0,0 +1,101 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshotFile is the format of snapshot written to disk
+type snapshotFile struct {
+	Revision int64     `json:"revision"`
+	SavedAt  time.Time `json:"savedAt"`
+
+	// Objects contains objects by kind
+	Objects map[string][]json.RawMessage `json:"objects"`
+}
+
+// WriteSnapshot writes the snapshot to the file. The file is replaced at once,
+// thus it contains either the previous or the new snapshot.
+func WriteSnapshot(path string, snapshot *Snapshot) error {
+	file := snapshotFile{
+		Revision: snapshot.Revision,
+		SavedAt:  time.Now(),
+		Objects:  make(map[string][]json.RawMessage),
+	}
+
+	for _, kind := range Kinds {
+		for _, item := range snapshot.List(kind) {
+			out, err := json.Marshal(item)
+			if err != nil {
+				return fmt.Errorf("Failed to encode %s: %s", kind, err)
+			}
+			file.Objects[kind] = append(file.Objects[kind], out)
+		}
+	}
+
+	out, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(path, out)
+}
+
+// ReadSnapshot reads the snapshot from the file and returns time when it was written
+func ReadSnapshot(path string) (*Snapshot, time.Time, error) {
+	var file snapshotFile
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, time.Time{}, fmt.Errorf("Failed to parse snapshot %s: %s", path, err)
+	}
+
+	snapshot := NewSnapshot(file.Revision)
+	for kind, items := range file.Objects {
+		for _, item := range items {
+			data, err := newObject(kind)
+			if err != nil {
+				return nil, time.Time{}, err
+			}
+
+			if err := json.Unmarshal(item, data); err != nil {
+				return nil, time.Time{}, fmt.Errorf("Failed to parse %s in snapshot %s: %s", kind, path, err)
+			}
+			snapshot.Objects[kind][objectName(data)] = data
+		}
+	}
+
+	return snapshot, file.SavedAt, nil
+}
+
+// writeFile writes data to a temporary file which replaces the file
+func writeFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(dir, "."+filepath.Base(path)+"-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,158 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/viper"
+	"github.com/tczekajlo/kir/pb"
+)
+
+// Kinds of objects kept in the store
+const (
+	Rule    = "rule"
+	Default = "default"
+	Policy  = "policy"
+	Set     = "set"
+	Binding = "binding"
+)
+
+// Backends of the store
+const (
+	// Etcd keeps objects in etcd, it is the default backend
+	Etcd = "etcd"
+
+	// Memory keeps objects in memory of the process, e.g. for local development
+	Memory = "memory"
+
+	// YAML keeps objects as YAML files in a directory, one file per object
+	YAML = "yaml"
+)
+
+// Store keeps rules and other objects of kir, i.e. defaults, Rego policies,
+// policy sets and bindings. Objects are identified by kind and name.
+type Store interface {
+	// Add stores the object. In the case when override is false the object is stored
+	// only if it does not exist, otherwise only if it exists. Objects which carry
+	// resource version, i.e. rules, are overridden only if the resource version is
+	// still the current one or it is not given. It returns false in the case
+	// when the object is not stored for that reason.
+	Add(kind, name string, data proto.Message, override bool) (bool, error)
+
+	// Get decodes the object into data, resource version of the object is set as well
+	Get(kind, name string, data proto.Message) error
+
+	// GetAll returns objects of the kind sorted by name, limit 0 means all objects
+	GetAll(kind string, limit int64) (*List, error)
+
+	// Delete deletes the object and returns number of deleted objects
+	Delete(kind, name string) (int64, error)
+
+	// Close releases resources of the store
+	Close() error
+}
+
+// TTLStore is a store which removes objects on its own after ttl
+type TTLStore interface {
+	Store
+
+	// AddWithTTL stores the object like Add, the object is removed after ttl
+	AddWithTTL(kind, name string, data proto.Message, override bool, ttl time.Duration) (bool, error)
+}
+
+// List contains objects of a kind
+type List struct {
+	Items []proto.Message
+
+	// Count is number of all objects of the kind,
+	// it is greater than number of items in the case when they are limited
+	Count int64
+
+	// Errors contains errors of objects which cannot be read by name,
+	// such objects are left out of items
+	Errors map[string]error
+}
+
+// New creates the store of backend given by store.backend setting
+func New() (Store, error) {
+	switch backend := viper.GetString("store.backend"); backend {
+	case Etcd, "":
+		s, err := NewEtcd()
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	case Memory:
+		return memory, nil
+	case YAML:
+		s, err := NewYAML(viper.GetString("store.yaml.dir"))
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("Store backend %s is not supported (%s, %s, %s)", backend, Etcd, Memory, YAML)
+	}
+}
+
+// newObject returns an empty object of the kind
+func newObject(kind string) (proto.Message, error) {
+	switch kind {
+	case Rule:
+		return &pb.Rule{}, nil
+	case Default:
+		return &pb.Default{}, nil
+	case Policy:
+		return &pb.Policy{}, nil
+	case Set:
+		return &pb.PolicySet{}, nil
+	case Binding:
+		return &pb.Binding{}, nil
+	}
+
+	return nil, fmt.Errorf("Kind %s is not supported", kind)
+}
+
+// resourceVersion returns resource version of the object, it is 0 for kinds without it
+func resourceVersion(data proto.Message) int64 {
+	if rule, ok := data.(*pb.Rule); ok {
+		return rule.ResourceVersion
+	}
+
+	return 0
+}
+
+// setResourceVersion sets resource version of the object in the case when the kind has it
+func setResourceVersion(data proto.Message, version int64) {
+	if rule, ok := data.(*pb.Rule); ok {
+		rule.ResourceVersion = version
+	}
+}
+
+// withoutResourceVersion returns the object without resource version, which is not
+// stored with the object because the store keeps it on its own
+func withoutResourceVersion(data proto.Message) proto.Message {
+	if resourceVersion(data) == 0 {
+		return data
+	}
+
+	data = proto.Clone(data)
+	setResourceVersion(data, 0)
+
+	return data
+}
+
+// notFound returns error of object which does not exist
+func notFound(kind string) error {
+	return fmt.Errorf("Cannot find %s", kind)
+}
+
+// limitNames returns first limit names, limit 0 means all names
+func limitNames(names []string, limit int64) []string {
+	if limit > 0 && int64(len(names)) > limit {
+		return names[:limit]
+	}
+
+	return names
+}
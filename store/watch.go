@@ -0,0 +1,173 @@
+package store
+
+import (
+	"context"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// Kinds contains all kinds of objects kept in the store
+var Kinds = []string{Rule, Default, Policy, Set, Binding}
+
+// WatchStore is a store which notifies about changes of objects
+type WatchStore interface {
+	Store
+
+	// Load returns all objects together with revision of the store at which they are read
+	Load() (*Snapshot, error)
+
+	// Watch sends changes of objects made after the revision until ctx is done.
+	// The channel is closed in the case when the watch ends.
+	Watch(ctx context.Context, revision int64) <-chan *WatchResponse
+
+	// Revision returns current revision of the store
+	Revision() (int64, error)
+}
+
+// Snapshot contains all objects of the store
+type Snapshot struct {
+	// Objects contains objects by kind and name
+	Objects map[string]map[string]proto.Message
+
+	// Revision is revision of the store at which objects are read,
+	// it is 0 in the case when the store has no revisions
+	Revision int64
+
+	// Errors contains errors of objects which cannot be decoded by kind/name,
+	// such objects are left out of the snapshot
+	Errors map[string]error
+}
+
+// Event describes change of an object
+type Event struct {
+	Kind string
+	Name string
+
+	// Data is nil in the case when the object is deleted
+	Data proto.Message
+
+	// Err is not nil in the case when the object cannot be decoded
+	Err error
+}
+
+// WatchResponse contains changes of objects up to the revision
+type WatchResponse struct {
+	Events   []*Event
+	Revision int64
+
+	// Compacted is true in the case when changes after the revision of watch are
+	// compacted, thus they are lost and objects have to be loaded again
+	Compacted bool
+
+	// Err is not nil in the case when the watch fails, the watch ends after it
+	Err error
+}
+
+// Load returns all objects of the store. Stores which cannot be watched
+// are read kind by kind, thus the snapshot has no revision. Objects which
+// cannot be read are reported by errors of the snapshot.
+func Load(s Store) (*Snapshot, error) {
+	if ws, ok := s.(WatchStore); ok {
+		return ws.Load()
+	}
+
+	snapshot := NewSnapshot(0)
+	for _, kind := range Kinds {
+		list, err := s.GetAll(kind, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range list.Items {
+			snapshot.Objects[kind][objectName(item)] = item
+		}
+		for name, err := range list.Errors {
+			snapshot.Errors[kind+"/"+name] = err
+		}
+	}
+
+	return snapshot, nil
+}
+
+// NewSnapshot creates an empty snapshot at the revision
+func NewSnapshot(revision int64) *Snapshot {
+	snapshot := &Snapshot{
+		Objects:  make(map[string]map[string]proto.Message),
+		Revision: revision,
+		Errors:   make(map[string]error),
+	}
+	for _, kind := range Kinds {
+		snapshot.Objects[kind] = make(map[string]proto.Message)
+	}
+
+	return snapshot
+}
+
+// Apply applies changes of objects to the snapshot. The object which cannot
+// be decoded is left out of the snapshot until it is changed again.
+func (s *Snapshot) Apply(events []*Event) {
+	for _, event := range events {
+		objects, ok := s.Objects[event.Kind]
+		if !ok {
+			continue
+		}
+
+		key := event.Kind + "/" + event.Name
+		delete(s.Errors, key)
+		if event.Err != nil {
+			delete(objects, event.Name)
+			s.Errors[key] = event.Err
+			continue
+		}
+
+		if event.Data == nil {
+			delete(objects, event.Name)
+			continue
+		}
+		objects[event.Name] = event.Data
+	}
+}
+
+// List returns objects of the kind sorted by name
+func (s *Snapshot) List(kind string) []proto.Message {
+	var names []string
+	for name := range s.Objects[kind] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []proto.Message
+	for _, name := range names {
+		result = append(result, s.Objects[kind][name])
+	}
+
+	return result
+}
+
+// ErrorList returns errors of objects which cannot be decoded sorted by kind/name
+func (s *Snapshot) ErrorList() []error {
+	var keys []string
+	for key := range s.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var result []error
+	for _, key := range keys {
+		result = append(result, s.Errors[key])
+	}
+
+	return result
+}
+
+// objectName returns name of the object, every kind of objects has a name
+func objectName(data proto.Message) string {
+	if named, ok := data.(interface {
+		GetName() string
+	}); ok {
+		return named.GetName()
+	}
+
+	return ""
+}
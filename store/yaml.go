@@ -0,0 +1,187 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/proto"
+)
+
+const yamlExt = ".yaml"
+
+// YAMLStore keeps objects as YAML files in a directory, every object is stored
+// in kind/name.yaml file, e.g. rule/banned.yaml. Files have the same format
+// as files of kir add -f command, so the directory can be kept in git.
+// Resource version of object is modification time of its file in nanoseconds.
+type YAMLStore struct {
+	// mutex guards check of existence and write of files within the process,
+	// files changed by other processes in the meantime are overwritten
+	mutex sync.Mutex
+	dir   string
+}
+
+// NewYAML creates the store which keeps objects in the directory
+func NewYAML(dir string) (*YAMLStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("Directory of YAML store is not given. Use --store-dir flag")
+	}
+
+	return &YAMLStore{dir: dir}, nil
+}
+
+// path returns path of the file of object
+func (s *YAMLStore) path(kind, name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("Invalid name %s, it cannot be used as a file name", name)
+	}
+
+	return filepath.Join(s.dir, kind, name+yamlExt), nil
+}
+
+// Add writes the object to a temporary file which replaces the file of object,
+// thus the file is never written partially
+func (s *YAMLStore) Add(kind, name string, data proto.Message, override bool) (bool, error) {
+	path, err := s.path(kind, name)
+	if err != nil {
+		return false, err
+	}
+
+	out, err := yaml.Marshal(withoutResourceVersion(data))
+	if err != nil {
+		return false, fmt.Errorf("Failed to encode %s: %s", kind, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	info, err := os.Stat(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	exists := err == nil
+	if exists != override {
+		return false, nil
+	}
+	if version := resourceVersion(data); exists && version != 0 && version != info.ModTime().UnixNano() {
+		return false, nil
+	}
+
+	if err := writeFile(path, out); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Get decodes the file of object into data
+func (s *YAMLStore) Get(kind, name string, data proto.Message) error {
+	path, err := s.path(kind, name)
+	if err != nil {
+		return err
+	}
+
+	return readYAML(kind, path, data)
+}
+
+// GetAll returns objects of the kind sorted by name. Hidden files
+// and files without .yaml extension are skipped, files which cannot be read
+// are reported by errors of the list.
+func (s *YAMLStore) GetAll(kind string, limit int64) (*List, error) {
+	files, err := ioutil.ReadDir(filepath.Join(s.dir, kind))
+	if os.IsNotExist(err) {
+		return &List{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") || filepath.Ext(file.Name()) != yamlExt {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(file.Name(), yamlExt))
+	}
+	sort.Strings(names)
+
+	result := &List{Count: int64(len(names))}
+	for _, name := range limitNames(names, limit) {
+		data, err := newObject(kind)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := readYAML(kind, filepath.Join(s.dir, kind, name+yamlExt), data); err != nil {
+			if result.Errors == nil {
+				result.Errors = make(map[string]error)
+			}
+			result.Errors[name] = err
+			continue
+		}
+		result.Items = append(result.Items, data)
+	}
+
+	return result, nil
+}
+
+// Delete removes the file of object
+func (s *YAMLStore) Delete(kind, name string) (int64, error) {
+	path, err := s.path(kind, name)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return 1, nil
+}
+
+// Close does nothing, files are written by Add
+func (s *YAMLStore) Close() error {
+	return nil
+}
+
+// readYAML decodes the file into data, resource version is set from modification time of the file.
+// Files are replaced, not written in place, thus the opened file has content of the modification time.
+func readYAML(kind, path string, data proto.Message) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return notFound(kind)
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(content, data); err != nil {
+		return fmt.Errorf("Failed to parse %s %s: %s", kind, path, err)
+	}
+	setResourceVersion(data, info.ModTime().UnixNano())
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+// RuleSetStatus describes the rule set which is used during review
+type RuleSetStatus struct {
+	// Rules is a number of rules which are used during review
+	Rules int `json:"rules"`
+	// Errors contains errors of rules, defaults and Rego policies which cannot be compiled
+	// +optional
+	Errors []string `json:"errors,omitempty"`
+	// Cache describes the cache of rules in the case when the server uses it
+	// +optional
+	Cache *CacheStatus `json:"cache,omitempty"`
+}
+
+// CacheStatus describes the cache of rules of the server
+type CacheStatus struct {
+	// Source is "store" or "snapshot" in the case when rules are loaded from
+	// the last-known-good snapshot, it is empty when rules are not loaded at all
+	// +optional
+	Source string `json:"source,omitempty"`
+	// Revision is the revision of store which the cache reflects,
+	// it is 0 in the case when the store has no revisions
+	Revision int64 `json:"revision"`
+	// SyncedAt is the time when the cache was confirmed to be up to date with the store
+	// +optional
+	SyncedAt *time.Time `json:"syncedAt,omitempty"`
+	// Staleness is the time elapsed since SyncedAt, e.g. "1.5s"
+	// +optional
+	Staleness string `json:"staleness,omitempty"`
+	// Stale is true in the case when the cache may miss changes of the store,
+	// e.g. the watch of store is broken, the store cannot be reached or rules
+	// are loaded from the last-known-good snapshot
+	Stale bool `json:"stale"`
+	// Reloads is a number of times when all rules were loaded again, e.g. after compaction
+	Reloads int `json:"reloads"`
+}
@@ -0,0 +1,57 @@
+package types
+
+// ReviewTrace explains how the decision for the request was taken
+type ReviewTrace struct {
+	// Strategy is a strategy of conflict resolution used during review
+	Strategy string `json:"strategy"`
+	// Containers contains traces of review of every container
+	Containers []*ContainerTrace `json:"containers"`
+	// Sets contains names of policy sets bound to the namespace of the request,
+	// it is empty in the case when there are no bindings and all rules are evaluated
+	// +optional
+	Sets []string `json:"sets,omitempty"`
+	// Errors contains errors of rules which cannot be compiled and are left out of review
+	// +optional
+	Errors []string `json:"errors,omitempty"`
+	// RegoDenies contains deny messages of Rego policies
+	// +optional
+	RegoDenies []string `json:"regoDenies,omitempty"`
+	// BreakGlass is a ticket of break-glass which overrode denies
+	// +optional
+	BreakGlass string `json:"breakGlass,omitempty"`
+}
+
+// ContainerTrace explains how the decision for a container was taken
+type ContainerTrace struct {
+	Image   string `json:"image"`
+	Allowed bool   `json:"allowed"`
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// DecidedBy is a name of the rule which produced the decision,
+	// or "default" in the case when none of rules matched
+	DecidedBy string `json:"decidedBy"`
+	// Rules contains results of every rule in order of evaluation
+	Rules []RuleTrace `json:"rules"`
+}
+
+// RuleTrace contains results of conditions of a rule for a container
+type RuleTrace struct {
+	Name     string `json:"name"`
+	Priority int32  `json:"priority"`
+	Mode     string `json:"mode"`
+	// Active is false in the case when the rule is outside its validity window
+	Active             bool `json:"active"`
+	ImageMatched       bool `json:"imageMatched"`
+	ImageExcluded      bool `json:"imageExcluded"`
+	NamespaceMatched   bool `json:"namespaceMatched"`
+	AnnotationsMatched bool `json:"annotationsMatched"`
+	// ConditionMatched is true also in the case when the rule has no condition
+	ConditionMatched bool `json:"conditionMatched"`
+	// Matched is true in the case when all of conditions are fulfilled
+	Matched bool `json:"matched"`
+	// Allowed is a decision of the rule, it is set only if the rule matched
+	// +optional
+	Allowed bool `json:"allowed,omitempty"`
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}